@@ -4,19 +4,46 @@ import (
 	"bytes"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"reflect"
 
 	"github.com/hashicorp/gorm-cursor-paginator/internal/util"
 )
 
+// causes of malformed cursor
+var (
+	errNotJSON      = errors.New("content is not json")
+	errNotJSONArray = errors.New("content is not json array")
+)
+
 // NewDecoder creates cursor decoder for model
 func NewDecoder(keys ...string) *Decoder {
-	return &Decoder{keys}
+	return &Decoder{keys: keys}
+}
+
+// NewStrictDecoder creates cursor decoder for model rejecting cursors not having exactly one element
+// per key, or exceeding limits, so that cursors from untrusted input can be decoded safely
+func NewStrictDecoder(limits Limits, keys ...string) *Decoder {
+	return &Decoder{keys: keys, strict: true, limits: limits}
+}
+
+// Limits of strict decoding, zero means no limit
+type Limits struct {
+	// MaxLength is max length of encoded cursor
+	MaxLength int
+	// MaxStringSize is max size in bytes of strings in cursor, including keys of objects
+	MaxStringSize int
+	// MaxDepth is max nesting depth of arrays and objects within elements of cursor
+	MaxDepth int
 }
 
 // Decoder cursor decoder
 type Decoder struct {
-	keys []string
+	keys   []string
+	strict bool
+	limits Limits
 }
 
 // Decode decodes cursor into values (without pointer) by referencing field type on model.
@@ -24,27 +51,47 @@ func (d *Decoder) Decode(cursor string, model interface{}) (fields []interface{}
 	if err = d.validate(model); err != nil {
 		return
 	}
+	if d.strict && d.limits.MaxLength > 0 && len(cursor) > d.limits.MaxLength {
+		return nil, &DecodeError{
+			Position: -1,
+			Cause:    fmt.Errorf("%w: %d > %d", ErrCursorTooLong, len(cursor), d.limits.MaxLength),
+		}
+	}
 	b, err := base64.StdEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, &DecodeError{Position: -1, Cause: err}
+	}
 	// ensure cursor content is json
-	if err != nil || !json.Valid(b) {
-		return nil, ErrInvalidCursor
+	if !json.Valid(b) {
+		return nil, &DecodeError{Position: -1, Cause: errNotJSON}
+	}
+	if d.strict {
+		if err = d.check(b, model); err != nil {
+			return
+		}
 	}
 	jd := json.NewDecoder(bytes.NewBuffer(b))
 	// ensure cursor content is json array
 	if t, err := jd.Token(); err != nil || t != json.Delim('[') {
-		return nil, ErrInvalidCursor
+		return nil, &DecodeError{Position: -1, Cause: errNotJSONArray}
 	}
-	for _, key := range d.keys {
+	for i, key := range d.keys {
+		if !jd.More() {
+			return nil, d.elementError(model, i, fmt.Errorf("%w: fewer than %d", ErrElementCount, len(d.keys)))
+		}
 		// key is already validated at beginning
 		f, _ := util.ReflectFieldByPath(model, key)
 		v := reflect.New(f.Type).Interface()
 		if err := jd.Decode(v); err != nil {
-			return nil, ErrInvalidCursor
+			return nil, d.elementError(model, i, err)
 		}
 		fields = append(fields, reflect.ValueOf(v).Elem().Interface())
 	}
 	// cursor must be a valid json after previous checks,
 	// so no need to check whether "]" is the last token
+	if d.strict && jd.More() {
+		return nil, d.elementError(model, len(d.keys), fmt.Errorf("%w: more than %d", ErrElementCount, len(d.keys)))
+	}
 	return
 }
 
@@ -65,12 +112,62 @@ func (d *Decoder) validate(model interface{}) error {
 	modelType := util.ReflectType(model)
 	// model's underlying type must be a struct
 	if modelType.Kind() != reflect.Struct {
-		return ErrInvalidModel
+		return &ModelError{Model: modelType}
 	}
 	for _, key := range d.keys {
 		if _, ok := util.ReflectFieldByPath(model, key); !ok {
-			return ErrInvalidModel
+			return &ModelError{Key: key, Model: modelType}
 		}
 	}
 	return nil
 }
+
+// check checks string sizes and nesting depth of json content against limits before values are decoded
+func (d *Decoder) check(b []byte, model interface{}) error {
+	jd := json.NewDecoder(bytes.NewBuffer(b))
+	depth, position := 0, -1
+	for {
+		t, err := jd.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return &DecodeError{Position: -1, Cause: err}
+		}
+		// tokens directly in the cursor array start its elements
+		if depth == 1 && t != json.Delim(']') {
+			position++
+		}
+		switch v := t.(type) {
+		case json.Delim:
+			if v == '[' || v == '{' {
+				depth++
+			} else {
+				depth--
+			}
+			// the cursor array itself is not counted
+			if d.limits.MaxDepth > 0 && depth-1 > d.limits.MaxDepth {
+				return d.elementError(model, position, fmt.Errorf("%w: > %d", ErrCursorTooDeep, d.limits.MaxDepth))
+			}
+		case string:
+			if d.limits.MaxStringSize > 0 && len(v) > d.limits.MaxStringSize {
+				return d.elementError(
+					model,
+					position,
+					fmt.Errorf("%w: %d > %d", ErrStringTooLong, len(v), d.limits.MaxStringSize),
+				)
+			}
+		}
+	}
+}
+
+// elementError returns DecodeError of element at position, key is empty for elements beyond keys
+func (d *Decoder) elementError(model interface{}, position int, cause error) *DecodeError {
+	e := &DecodeError{Position: position, Cause: cause}
+	if position >= 0 && position < len(d.keys) {
+		// key is already validated at beginning
+		f, _ := util.ReflectFieldByPath(model, d.keys[position])
+		e.Key, e.Type = d.keys[position], f.Type
+	}
+	return e
+}
@@ -2,6 +2,8 @@ package cursor
 
 import (
 	"encoding/base64"
+	"errors"
+	"reflect"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
@@ -19,12 +21,12 @@ type decoderSuite struct {
 
 func (s *decoderSuite) TestDecodeKeyNotMatchedModel() {
 	_, err := NewDecoder("Key").Decode("cursor", struct{ ID string }{})
-	s.Equal(ErrInvalidModel, err)
+	s.True(errors.Is(err, ErrInvalidModel))
 }
 
 func (s *decoderSuite) TestDecodeNonStructModel() {
 	_, err := NewDecoder("Key").Decode("cursor", 123)
-	s.Equal(ErrInvalidModel, err)
+	s.True(errors.Is(err, ErrInvalidModel))
 }
 
 func (s *decoderSuite) TestDecodeInvalidCursorFormat() {
@@ -35,33 +37,148 @@ func (s *decoderSuite) TestDecodeInvalidCursorFormat() {
 
 	// cursor must be a base64 encoded string
 	_, err := d.Decode("123", model{})
-	s.Equal(ErrInvalidCursor, err)
+	s.True(errors.Is(err, ErrInvalidCursor))
 
 	// cursor must be a valid json
 	c := base64.StdEncoding.EncodeToString([]byte(`["123"}`))
 	_, err = d.Decode(c, model{})
-	s.Equal(ErrInvalidCursor, err)
+	s.True(errors.Is(err, ErrInvalidCursor))
 
 	// cursor must be a json array
 	c = base64.StdEncoding.EncodeToString([]byte(`{"value": "123"}`))
 	_, err = d.Decode(c, model{})
-	s.Equal(ErrInvalidCursor, err)
+	s.True(errors.Is(err, ErrInvalidCursor))
 }
 
 func (s *decoderSuite) TestDecodeInvalidCursorType() {
 	c, _ := NewEncoder("Value").Encode(struct{ Value int }{123})
 	_, err := NewDecoder("Value").Decode(c, struct{ Value string }{})
-	s.Equal(ErrInvalidCursor, err)
+	s.True(errors.Is(err, ErrInvalidCursor))
+}
+
+func (s *decoderSuite) TestDecodeError() {
+	c, _ := NewEncoder("ID", "Value").Encode(struct {
+		ID    int
+		Value int
+	}{1, 123})
+	type model struct {
+		ID    int
+		Value string
+		Extra string
+	}
+
+	_, err := NewDecoder("ID", "Value").Decode(c, model{})
+	var e *DecodeError
+	s.Require().True(errors.As(err, &e))
+	s.Equal("Value", e.Key)
+	s.Equal(reflect.TypeOf(""), e.Type)
+	s.Equal(1, e.Position)
+	s.NotNil(e.Cause)
+
+	// cursor has fewer elements than keys
+	_, err = NewDecoder("ID", "Extra", "Value").Decode(c, model{})
+	s.Require().True(errors.As(err, &e))
+	s.Equal("Extra", e.Key)
+	s.Equal(1, e.Position)
+
+	// cursor is malformed as a whole
+	_, err = NewDecoder("ID").Decode("123", model{})
+	s.Require().True(errors.As(err, &e))
+	s.Equal(-1, e.Position)
+	s.True(errors.Is(err, ErrInvalidCursor))
+}
+
+func (s *decoderSuite) TestDecodeModelError() {
+	_, err := NewDecoder("Key").Decode("cursor", struct{ ID string }{})
+	var e *ModelError
+	s.Require().True(errors.As(err, &e))
+	s.Equal("Key", e.Key)
+	s.Equal(reflect.TypeOf(struct{ ID string }{}), e.Model)
+}
+
+/* strict decode */
+
+func (s *decoderSuite) TestStrictDecodeElementCount() {
+	type model struct {
+		ID    int
+		Value string
+	}
+	c, _ := NewEncoder("ID", "Value").Encode(model{1, "a"})
+
+	// extra elements are accepted unless decoding strictly
+	fields, err := NewDecoder("ID").Decode(c, model{})
+	s.Nil(err)
+	s.Equal([]interface{}{1}, fields)
+
+	_, err = NewStrictDecoder(Limits{}, "ID").Decode(c, model{})
+	var e *DecodeError
+	s.Require().True(errors.As(err, &e))
+	s.Equal(1, e.Position)
+	s.Equal("", e.Key)
+	s.True(errors.Is(err, ErrElementCount))
+	s.True(errors.Is(err, ErrInvalidCursor))
+
+	fields, err = NewStrictDecoder(Limits{}, "ID", "Value").Decode(c, model{})
+	s.Nil(err)
+	s.Equal([]interface{}{1, "a"}, fields)
+}
+
+func (s *decoderSuite) TestStrictDecodeMaxLength() {
+	type model struct {
+		Value string
+	}
+	c, _ := NewEncoder("Value").Encode(model{"abcdefgh"})
+
+	_, err := NewStrictDecoder(Limits{MaxLength: len(c) - 1}, "Value").Decode(c, model{})
+	s.True(errors.Is(err, ErrCursorTooLong))
+
+	_, err = NewStrictDecoder(Limits{MaxLength: len(c)}, "Value").Decode(c, model{})
+	s.Nil(err)
+}
+
+func (s *decoderSuite) TestStrictDecodeMaxStringSize() {
+	type model struct {
+		ID    int
+		Value string
+	}
+	c, _ := NewEncoder("ID", "Value").Encode(model{1, "abcd"})
+
+	_, err := NewStrictDecoder(Limits{MaxStringSize: 3}, "ID", "Value").Decode(c, model{})
+	var e *DecodeError
+	s.Require().True(errors.As(err, &e))
+	s.Equal("Value", e.Key)
+	s.Equal(1, e.Position)
+	s.True(errors.Is(err, ErrStringTooLong))
+
+	_, err = NewStrictDecoder(Limits{MaxStringSize: 4}, "ID", "Value").Decode(c, model{})
+	s.Nil(err)
+}
+
+func (s *decoderSuite) TestStrictDecodeMaxDepth() {
+	type model struct {
+		Value interface{}
+	}
+	c := base64.StdEncoding.EncodeToString([]byte(`[[[1]]]`))
+
+	_, err := NewStrictDecoder(Limits{MaxDepth: 1}, "Value").Decode(c, model{})
+	var e *DecodeError
+	s.Require().True(errors.As(err, &e))
+	s.Equal("Value", e.Key)
+	s.Equal(0, e.Position)
+	s.True(errors.Is(err, ErrCursorTooDeep))
+
+	_, err = NewStrictDecoder(Limits{MaxDepth: 2}, "Value").Decode(c, model{})
+	s.Nil(err)
 }
 
 /* decode struct */
 
 func (s *decoderSuite) TestDecodeStructInvalidModel() {
 	err := NewDecoder("Value").DecodeStruct("123", struct{ ID string }{})
-	s.Equal(ErrInvalidModel, err)
+	s.True(errors.Is(err, ErrInvalidModel))
 }
 
 func (s *decoderSuite) TestDecodeStructInvalidCursor() {
 	err := NewDecoder("Value").DecodeStruct("123", struct{ Value string }{})
-	s.Equal(ErrInvalidCursor, err)
+	s.True(errors.Is(err, ErrInvalidCursor))
 }
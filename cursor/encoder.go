@@ -32,7 +32,7 @@ func (e *Encoder) marshalJSON(model interface{}) ([]byte, error) {
 	for i, key := range e.keys {
 		f := util.ReflectValueByPath(model, key)
 		if f == (reflect.Value{}) {
-			return nil, ErrInvalidModel
+			return nil, &ModelError{Key: key, Model: util.ReflectType(model)}
 		}
 		if e.isNilable(f) && f.IsZero() {
 			fields[i] = nil
@@ -1,6 +1,7 @@
 package cursor
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
@@ -17,7 +18,7 @@ type encoderSuite struct {
 func (s *encoderSuite) TestInvalidModel() {
 	e := NewEncoder("ID")
 	_, err := e.Encode(struct{}{})
-	s.Equal(ErrInvalidModel, err)
+	s.True(errors.Is(err, ErrInvalidModel))
 }
 
 func (s *encoderSuite) TestInvalidModelFieldType() {
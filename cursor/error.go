@@ -1,9 +1,71 @@
 package cursor
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
 
 // Errors for encoder
 var (
 	ErrInvalidCursor = errors.New("invalid cursor")
 	ErrInvalidModel  = errors.New("invalid model")
 )
+
+// Causes of DecodeError rejecting cursor
+var (
+	ErrCursorTooDeep = errors.New("cursor is nested too deep")
+	ErrCursorTooLong = errors.New("cursor is too long")
+	ErrElementCount  = errors.New("cursor does not have one element per key")
+	ErrStringTooLong = errors.New("string in cursor is too long")
+)
+
+// DecodeError describes why cursor cannot be decoded, it matches ErrInvalidCursor by errors.Is
+type DecodeError struct {
+	// Key of the element failing decoding, empty when cursor is malformed as a whole or element is beyond keys
+	Key string
+	// Type is Go type expected for the element
+	Type reflect.Type
+	// Position of the element in cursor, -1 when cursor is malformed as a whole
+	Position int
+	Cause    error
+}
+
+func (e *DecodeError) Error() string {
+	switch {
+	case e.Position < 0:
+		return fmt.Sprintf("%s: %v", ErrInvalidCursor, e.Cause)
+	case e.Key == "":
+		return fmt.Sprintf("%s: position %d: %v", ErrInvalidCursor, e.Position, e.Cause)
+	}
+	return fmt.Sprintf("%s: key %s (%v) at position %d: %v", ErrInvalidCursor, e.Key, e.Type, e.Position, e.Cause)
+}
+
+// Unwrap returns cause of failure
+func (e *DecodeError) Unwrap() error {
+	return e.Cause
+}
+
+// Is reports whether target is ErrInvalidCursor
+func (e *DecodeError) Is(target error) bool {
+	return target == ErrInvalidCursor
+}
+
+// ModelError describes key not found on model, it matches ErrInvalidModel by errors.Is
+type ModelError struct {
+	// Key not found on model, empty when model is not a struct
+	Key   string
+	Model reflect.Type
+}
+
+func (e *ModelError) Error() string {
+	if e.Key == "" {
+		return fmt.Sprintf("%s: %v is not a struct", ErrInvalidModel, e.Model)
+	}
+	return fmt.Sprintf("%s: key %s not found on %v", ErrInvalidModel, e.Key, e.Model)
+}
+
+// Is reports whether target is ErrInvalidModel
+func (e *ModelError) Is(target error) bool {
+	return target == ErrInvalidModel
+}
@@ -0,0 +1,90 @@
+package paginator
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// BatchProgress reports progress of EachBatch once a batch is committed
+type BatchProgress struct {
+	// Batches is number of committed batches
+	Batches int
+	// Rows is number of rows in committed batches
+	Rows int
+	// After is cursor of the last row of committed batches, it resumes the rest with WithAfter
+	After string
+}
+
+// EachBatch walks rows of db forward in batches of limit rows, each batch is read into dest and
+// then mutated by fn inside its own transaction, e.g., updating or deleting rows of dest by keys.
+// fn takes the transaction without conditions of db. Paging keys should not be changed by fn,
+// otherwise rows could be visited again or skipped.
+//
+// progress, if not nil, is called once a batch is committed. Batches are separated by throttle,
+// EachBatch returns when there is no more row, ctx is done or fn returns an error.
+func (p *Paginator) EachBatch(
+	ctx context.Context,
+	db *gorm.DB,
+	dest interface{},
+	fn func(tx *gorm.DB) error,
+	progress func(BatchProgress),
+) error {
+	wp := p.newWalker()
+	var state BatchProgress
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		after, more, err := wp.walkBatch(db, dest, fn)
+		if err != nil || after == nil {
+			return err
+		}
+		state.Batches++
+		state.Rows += reflect.ValueOf(dest).Elem().Len()
+		state.After = *after
+		if progress != nil {
+			progress(state)
+		}
+		if !more {
+			return nil
+		}
+		if p.throttle > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(p.throttle):
+			}
+		}
+	}
+}
+
+/* private */
+
+// walkBatch walks a page inside transaction and calls fn with it, after cursor of walker
+// is moved forward only when transaction is committed
+func (p *Paginator) walkBatch(db *gorm.DB, dest interface{}, fn func(tx *gorm.DB) error) (after *string, more bool, err error) {
+	tx := db.Begin()
+	if err = tx.Error; err != nil {
+		return
+	}
+	c := p.cursor
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			p.cursor = c
+		}
+	}()
+	if after, more, err = p.walk(tx, dest); err != nil {
+		return
+	}
+	if after != nil {
+		if err = fn(tx.New()); err != nil {
+			return
+		}
+	}
+	err = tx.Commit().Error
+	return
+}
@@ -0,0 +1,246 @@
+package paginator
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// Cache of pages, it should be safe for concurrent use
+type Cache interface {
+	// Get returns value cached by key
+	Get(key string) (value interface{}, ok bool)
+	// Set caches value by key for ttl, value never expires when ttl is 0
+	Set(key string, value interface{}, ttl time.Duration)
+}
+
+// LRUCache is an in-memory cache evicting least recently used entries beyond capacity
+type LRUCache struct {
+	mu       sync.Mutex
+	capacity int
+	entries  *list.List
+	index    map[string]*list.Element
+	// now is clock of expiration
+	now func() time.Time
+}
+
+// NewLRUCache creates LRU cache holding at most capacity entries, capacity should be greater than 0
+// as nothing is held otherwise
+func NewLRUCache(capacity int) *LRUCache {
+	return &LRUCache{
+		capacity: capacity,
+		entries:  list.New(),
+		index:    make(map[string]*list.Element),
+		now:      time.Now,
+	}
+}
+
+// Get implements Cache
+func (c *LRUCache) Get(key string) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.index[key]
+	if !ok {
+		return nil, false
+	}
+	entry := el.Value.(*lruEntry)
+	if entry.expired(c.now()) {
+		c.entries.Remove(el)
+		delete(c.index, key)
+		return nil, false
+	}
+	c.entries.MoveToFront(el)
+	return entry.value, true
+}
+
+// Set implements Cache
+func (c *LRUCache) Set(key string, value interface{}, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry := &lruEntry{
+		key:   key,
+		value: value,
+	}
+	if ttl > 0 {
+		entry.expiresAt = c.now().Add(ttl)
+	}
+	if el, ok := c.index[key]; ok {
+		el.Value = entry
+		c.entries.MoveToFront(el)
+		return
+	}
+	c.index[key] = c.entries.PushFront(entry)
+	for c.entries.Len() > c.capacity {
+		el := c.entries.Back()
+		c.entries.Remove(el)
+		delete(c.index, el.Value.(*lruEntry).key)
+	}
+}
+
+// Len returns number of entries, including expired ones not evicted yet
+func (c *LRUCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.entries.Len()
+}
+
+/* private */
+
+type lruEntry struct {
+	key       string
+	value     interface{}
+	expiresAt time.Time
+}
+
+func (e *lruEntry) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && now.After(e.expiresAt)
+}
+
+// cachedPage is a copy of page, so that cached rows are not affected by callers
+type cachedPage struct {
+	rows   reflect.Value
+	cursor Cursor
+}
+
+// prefetching holds fingerprints of pages being prefetched, so that a page is prefetched only once at a time
+var prefetching sync.Map
+
+// paginateCached serves page from cache by fingerprint of paging statement, result is db itself on cache hit
+func (p *Paginator) paginateCached(db *gorm.DB, dest interface{}, fields []interface{}) (result *gorm.DB, c Cursor, err error) {
+	elems := reflect.ValueOf(dest).Elem()
+	if elems.Kind() != reflect.Slice {
+		return p.paginate(db, dest, fields)
+	}
+	key := p.fingerprint(db, dest, fields)
+	if v, ok := p.cache.Get(key); ok {
+		page := v.(cachedPage)
+		elems.Set(deepCopy(page.rows))
+		result, c = db, page.cursor
+	} else {
+		if result, c, err = p.paginate(db, dest, fields); err != nil || result.Error != nil {
+			return
+		}
+		p.cache.Set(key, cachedPage{rows: deepCopy(elems), cursor: c}, p.cacheTTL)
+	}
+	if p.prefetch {
+		p.prefetchNext(db, elems.Type(), c)
+	}
+	return
+}
+
+// prefetchNext fetches the next page in paging direction into cache in background, unless it is cached
+// or being prefetched already. Statements within transaction are not prefetched, as transaction may be
+// done once the page is served.
+func (p *Paginator) prefetchNext(db *gorm.DB, sliceType reflect.Type, c Cursor) {
+	if _, ok := db.CommonDB().(*sql.Tx); ok {
+		return
+	}
+	np := *p
+	np.SetRules(p.rules...)
+	np.prefetch = false
+	if p.isBackward() {
+		np.cursor = Cursor{Before: c.Before}
+	} else {
+		np.cursor = Cursor{After: c.After}
+	}
+	if np.cursor.After == nil && np.cursor.Before == nil {
+		return
+	}
+	dest := reflect.New(sliceType).Interface()
+	fields, err := np.prepare(db, dest)
+	if err != nil {
+		return
+	}
+	key := np.fingerprint(db, dest, fields)
+	if _, ok := p.cache.Get(key); ok {
+		return
+	}
+	if _, ok := prefetching.LoadOrStore(key, true); ok {
+		return
+	}
+	go func() {
+		defer prefetching.Delete(key)
+		// errors are left to the request actually asking for the page
+		result, c, err := np.paginate(db, dest, fields)
+		if err == nil && result.Error == nil {
+			p.cache.Set(key, cachedPage{rows: deepCopy(reflect.ValueOf(dest).Elem()), cursor: c}, p.cacheTTL)
+		}
+	}()
+}
+
+// fingerprint hashes paging statement with its args, database running it, type of dest and cursor
+func (p *Paginator) fingerprint(db *gorm.DB, dest interface{}, fields []interface{}) string {
+	h := sha256.New()
+	// the same statement may run on different databases, which are told apart by connection pool or transaction
+	fmt.Fprintf(h, "%p\x00%T\x00", db.CommonDB(), dest)
+	// raw statement of paging query expands it into SQL and args of scope
+	scope := newWrappingStmt(db).Raw("?", p.appendPagingQuery(db, fields).Model(dest).QueryExpr()).NewScope(nil)
+	fmt.Fprintf(h, "%s\x00", scope.CombinedConditionSql())
+	for _, arg := range scope.SQLVars {
+		// values pointed to instead of addresses
+		if v := reflect.Indirect(reflect.ValueOf(arg)); v.IsValid() {
+			fmt.Fprintf(h, "%v\x00", v)
+		} else {
+			fmt.Fprint(h, "NULL\x00")
+		}
+	}
+	for _, c := range []*string{p.cursor.After, p.cursor.Before} {
+		if c != nil {
+			fmt.Fprintf(h, "%s", *c)
+		}
+		fmt.Fprint(h, "\x00")
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// deepCopy copies v along with values referred to by exported fields, so that cached rows are not
+// affected by callers, values referred to by unexported fields are shared
+func deepCopy(v reflect.Value) reflect.Value {
+	c := reflect.New(v.Type()).Elem()
+	switch v.Kind() {
+	case reflect.Ptr:
+		if !v.IsNil() {
+			c.Set(reflect.New(v.Type().Elem()))
+			c.Elem().Set(deepCopy(v.Elem()))
+		}
+	case reflect.Interface:
+		if !v.IsNil() {
+			c.Set(deepCopy(v.Elem()))
+		}
+	case reflect.Slice:
+		if !v.IsNil() {
+			c.Set(reflect.MakeSlice(v.Type(), v.Len(), v.Len()))
+			for i := 0; i < v.Len(); i++ {
+				c.Index(i).Set(deepCopy(v.Index(i)))
+			}
+		}
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(deepCopy(v.Index(i)))
+		}
+	case reflect.Map:
+		if !v.IsNil() {
+			c.Set(reflect.MakeMapWithSize(v.Type(), v.Len()))
+			for iter := v.MapRange(); iter.Next(); {
+				c.SetMapIndex(iter.Key(), deepCopy(iter.Value()))
+			}
+		}
+	case reflect.Struct:
+		c.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if c.Field(i).CanSet() {
+				c.Field(i).Set(deepCopy(v.Field(i)))
+			}
+		}
+	default:
+		c.Set(v)
+	}
+	return c
+}
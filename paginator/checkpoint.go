@@ -0,0 +1,182 @@
+package paginator
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// CheckpointStore stores after cursors of jobs, so that jobs can be resumed after restarts
+type CheckpointStore interface {
+	// Load returns after cursor saved for job, ok is false when there is no checkpoint
+	Load(job string) (cursor string, ok bool, err error)
+	// Save saves after cursor for job
+	Save(job string, cursor string) error
+}
+
+// Iterate returns an iterator walking pages forward for job, it starts from the checkpoint saved in store,
+// or after cursor of paginator when there is none. Checkpoint is saved whenever a page is committed:
+//
+//	it := p.Iterate(db, &users, store, "backfill")
+//	for it.Next() {
+//	    // process users
+//	    if err := it.Commit(); err != nil {
+//	        // ...
+//	    }
+//	}
+//	if err := it.Err(); err != nil {
+//	    // ...
+//	}
+func (p *Paginator) Iterate(db *gorm.DB, dest interface{}, store CheckpointStore, job string) *Iterator {
+	return &Iterator{
+		walker: p.newWalker(),
+		db:     db,
+		dest:   dest,
+		store:  store,
+		job:    job,
+	}
+}
+
+// Iterator walks pages of a job, see Paginator.Iterate
+type Iterator struct {
+	walker *Paginator
+	db     *gorm.DB
+	dest   interface{}
+	store  CheckpointStore
+	job    string
+	// after is cursor of the last row of current page
+	after   *string
+	started bool
+	done    bool
+	err     error
+}
+
+// Next paginates the next page into dest, it returns false when there is no more page or an error occurs
+func (it *Iterator) Next() bool {
+	if it.done || it.err != nil {
+		return false
+	}
+	resumed := false
+	if !it.started {
+		it.started = true
+		c, ok, err := it.store.Load(it.job)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		if ok {
+			it.walker.cursor.After = &c
+			resumed = true
+		}
+	}
+	after, more, err := it.walker.walk(it.db, it.dest)
+	if err != nil {
+		// e.g., checkpoint saved under different rules
+		if resumed && errors.Is(err, ErrInvalidCursor) {
+			err = &CheckpointError{Job: it.job, Cause: err}
+		}
+		it.err = err
+		return false
+	}
+	if after == nil {
+		it.done = true
+		return false
+	}
+	it.after = after
+	it.done = !more
+	return true
+}
+
+// Commit saves checkpoint after current page, a restarted job continues from the next page
+func (it *Iterator) Commit() error {
+	if it.after == nil {
+		return nil
+	}
+	return it.store.Save(it.job, *it.after)
+}
+
+// Err returns error stopping iteration
+func (it *Iterator) Err() error {
+	return it.err
+}
+
+// MemoryCheckpointStore stores checkpoints in memory, it is safe for concurrent use
+type MemoryCheckpointStore struct {
+	mu      sync.Mutex
+	cursors map[string]string
+}
+
+// NewMemoryCheckpointStore creates memory checkpoint store
+func NewMemoryCheckpointStore() *MemoryCheckpointStore {
+	return &MemoryCheckpointStore{
+		cursors: make(map[string]string),
+	}
+}
+
+// Load implements CheckpointStore
+func (s *MemoryCheckpointStore) Load(job string) (string, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.cursors[job]
+	return c, ok, nil
+}
+
+// Save implements CheckpointStore
+func (s *MemoryCheckpointStore) Save(job string, cursor string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cursors[job] = cursor
+	return nil
+}
+
+// Checkpoint is a row of GormCheckpointStore
+type Checkpoint struct {
+	Job       string `gorm:"type:varchar(255);primary_key"`
+	Cursor    string `gorm:"type:text;not null"`
+	UpdatedAt time.Time
+}
+
+// TableName of checkpoints
+func (c Checkpoint) TableName() string {
+	return "paginator_checkpoints"
+}
+
+// GormCheckpointStore stores checkpoints in table "paginator_checkpoints"
+type GormCheckpointStore struct {
+	db *gorm.DB
+}
+
+// NewGormCheckpointStore creates gorm checkpoint store
+func NewGormCheckpointStore(db *gorm.DB) *GormCheckpointStore {
+	return &GormCheckpointStore{
+		db: db,
+	}
+}
+
+// AutoMigrate creates checkpoints table when it does not exist
+func (s *GormCheckpointStore) AutoMigrate() error {
+	return s.db.AutoMigrate(&Checkpoint{}).Error
+}
+
+// Load implements CheckpointStore
+func (s *GormCheckpointStore) Load(job string) (string, bool, error) {
+	var c Checkpoint
+	if err := s.db.Where("job = ?", job).First(&c).Error; err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return "", false, nil
+		}
+		return "", false, err
+	}
+	return c.Cursor, true, nil
+}
+
+// Save implements CheckpointStore
+func (s *GormCheckpointStore) Save(job string, cursor string) error {
+	return s.db.
+		Where(Checkpoint{Job: job}).
+		Assign(Checkpoint{Cursor: cursor}).
+		FirstOrCreate(&Checkpoint{}).
+		Error
+}
@@ -0,0 +1,155 @@
+package paginator
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/hashicorp/gorm-cursor-paginator/internal/util"
+)
+
+const (
+	// childrenAlias is the alias of derived table numbering children
+	childrenAlias = "page_children"
+	// childRowColumn is the column numbering children of each parent in paging order
+	childRowColumn = "page_row"
+)
+
+// Children is a page of children of a parent
+type Children struct {
+	// Items is a slice of the same type as dest
+	Items  interface{}
+	Cursor Cursor
+}
+
+// PaginateChildren paginates children of every parent in a single query, instead of paginating once
+// per parent. Key is the field of dest referring to parent (e.g., "OrderID"), and cursor of paginator
+// applies to children of every parent. Pages are keyed by parent converted to type of key, and dest is
+// filled with children of all parents in order of parents. Dialect should support window functions.
+func (p *Paginator) PaginateChildren(
+	db *gorm.DB, dest interface{}, key string, parents ...interface{},
+) (pages map[interface{}]Children, err error) {
+	f, ok := util.ReflectFieldByPath(dest, key)
+	if !ok {
+		return nil, &KeyError{Key: key, Err: ErrInvalidModel}
+	}
+	keys, err := parentKeys(parents, f, key)
+	if err != nil {
+		return
+	}
+	fields, err := p.prepare(db, dest)
+	if err != nil {
+		return
+	}
+	if len(keys) > 0 {
+		if err = p.appendChildrenPagingQuery(db, dest, fields, key, keys).Find(dest).Error; err != nil {
+			return
+		}
+	}
+	// rows are grouped by parent in paging order, with one more row for checking next page
+	groups := make(map[interface{}]reflect.Value, len(keys))
+	elems := reflect.ValueOf(dest).Elem()
+	for _, k := range keys {
+		groups[k] = reflect.MakeSlice(elems.Type(), 0, 0)
+	}
+	for i := 0; i < elems.Len(); i++ {
+		k := reflect.Indirect(util.ReflectValueByPath(elems.Index(i), key)).Interface()
+		groups[k] = reflect.Append(groups[k], elems.Index(i))
+	}
+	pages = make(map[interface{}]Children, len(keys))
+	all := reflect.MakeSlice(elems.Type(), 0, elems.Len())
+	for _, k := range keys {
+		children := Children{Items: groups[k].Interface()}
+		if group := groups[k]; group.Len() > 0 {
+			hasMore := group.Len() > p.limit
+			if hasMore {
+				group = group.Slice(0, group.Len()-1)
+			}
+			if p.isBackward() {
+				group = reverse(group)
+			}
+			if children.Cursor, err = p.encodeCursor(group, hasMore); err != nil {
+				return nil, err
+			}
+			children.Items = group.Interface()
+			all = reflect.AppendSlice(all, group)
+		}
+		pages[k] = children
+	}
+	elems.Set(all)
+	return
+}
+
+/* private */
+
+// parentKeys converts parents to type of key field f, duplicates are dropped
+func parentKeys(parents []interface{}, f reflect.StructField, key string) (keys []interface{}, err error) {
+	t := util.ReflectType(f.Type)
+	seen := make(map[interface{}]bool, len(parents))
+	for _, parent := range parents {
+		v := reflect.ValueOf(parent)
+		// numbers convert to each other, but not to strings
+		if !v.IsValid() ||
+			!v.Type().ConvertibleTo(t) ||
+			(v.Kind() != t.Kind() && !(isNumberKind(v.Kind()) && isNumberKind(t.Kind()))) {
+			return nil, &KeyError{Key: key, Type: f.Type, Err: ErrInvalidModel}
+		}
+		k := v.Convert(t).Interface()
+		if !seen[k] {
+			seen[k] = true
+			keys = append(keys, k)
+		}
+	}
+	return
+}
+
+// appendChildrenPagingQuery numbers children of each parent in paging order by window function
+// and takes a page from every parent, e.g.,
+// SELECT * FROM (SELECT items.*, ROW_NUMBER() OVER (PARTITION BY items.order_id ORDER BY ...) AS page_row
+// FROM items WHERE items.order_id IN (...) AND ...) AS page_children WHERE page_row <= ? ORDER BY order_id, page_row
+func (p *Paginator) appendChildrenPagingQuery(
+	db *gorm.DB, dest interface{}, fields []interface{}, key string, keys []interface{},
+) *gorm.DB {
+	var sqlTable string
+	keySQLRepr := p.buildSQLRepr(db, dest, key, &sqlTable)
+	order, orderArgs := p.buildOrderSQL()
+	numbering := fmt.Sprintf(
+		"ROW_NUMBER() OVER (PARTITION BY %s ORDER BY %s) AS %s",
+		keySQLRepr,
+		order,
+		childRowColumn,
+	)
+	// keys are expanded by hand, as nested expressions do not expand slices
+	in := fmt.Sprintf("%s IN (%s)", keySQLRepr, strings.TrimSuffix(strings.Repeat("?, ", len(keys)), ", "))
+	var inner interface{}
+	if p.subquery {
+		query, args := p.buildWrappedQuery(
+			db,
+			fields,
+			fmt.Sprintf("%s.*, %s", subqueryAlias, numbering),
+			orderArgs,
+			[]string{in},
+			keys,
+		)
+		inner = gorm.Expr(query, args...)
+	} else {
+		inner = p.appendPagingQuery(db, fields).
+			Model(dest).
+			Where(in, keys...).
+			Select(fmt.Sprintf("%s.*, %s", db.NewScope(dest).QuotedTableName(), numbering), orderArgs...).
+			Limit(-1).
+			QueryExpr()
+	}
+	return newWrappingStmt(db).Raw(
+		fmt.Sprintf(
+			"SELECT * FROM (?) AS %[1]s WHERE %[2]s <= ? ORDER BY %[1]s.%[3]s, %[2]s",
+			childrenAlias,
+			childRowColumn,
+			p.parseSQLKey(dest, key),
+		),
+		inner,
+		p.limit+1,
+	)
+}
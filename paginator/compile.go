@@ -0,0 +1,137 @@
+package paginator
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/hashicorp/gorm-cursor-paginator/internal/util"
+)
+
+// Compiled is configuration of paginator bound to a model type, see Compile
+type Compiled struct {
+	base Paginator
+	// rules and snapshot as configured, before columns are resolved
+	rules    []Rule
+	snapshot *Rule
+	model    reflect.Type
+	codec    *cursorCodec
+}
+
+// Compile binds rules of paginator configured by opts to type of model (e.g., User{} or &[]User{}) once,
+// typically at startup. Keys are validated to exist with orderable types, columns are resolved by db and
+// cursor types are cached, so that configuration errors are reported here instead of by every request.
+func Compile(db *gorm.DB, model interface{}, opts ...Option) (*Compiled, error) {
+	t := util.ReflectType(model)
+	if t.Kind() != reflect.Struct {
+		return nil, ErrInvalidModel
+	}
+	dest := reflect.New(reflect.SliceOf(t)).Interface()
+	p := New(opts...)
+	if err := p.validate(dest); err != nil {
+		return nil, err
+	}
+	keys := p.rules
+	if p.snapshot != nil {
+		keys = append(keys[:len(keys):len(keys)], *p.snapshot)
+	}
+	for _, rule := range keys {
+		if ct := rule.cursorType(dest); !isOrderableType(ct) {
+			return nil, &KeyError{Key: rule.Key, Type: ct, Err: ErrUnorderableKey}
+		}
+	}
+	c := &Compiled{
+		rules: append([]Rule(nil), p.rules...),
+		model: t,
+	}
+	if p.snapshot != nil {
+		snapshot := *p.snapshot
+		c.snapshot = &snapshot
+	}
+	// columns are resolved once, while orders are resolved on every call as they can be overridden
+	var sqlTable string
+	for i := range p.rules {
+		if p.rules[i].SQLRepr == "" {
+			p.rules[i].SQLRepr = p.buildSQLRepr(db, dest, p.rules[i].Key, &sqlTable)
+		}
+	}
+	if p.snapshot != nil && p.snapshot.SQLRepr == "" {
+		p.snapshot.SQLRepr = p.buildSQLRepr(db, dest, p.snapshot.Key, &sqlTable)
+	}
+	c.base = *p
+	c.codec = p.newCursorCodec(dest)
+	return c, nil
+}
+
+// New creates paginator of the model type, opts (e.g., WithAfter) are applied over compiled configuration.
+// Paginator is validated and set up on every call as usual when opts change rules, snapshot key or subquery.
+func (c *Compiled) New(opts ...Option) *Paginator {
+	p := c.base
+	p.SetRules(c.base.rules...)
+	if c.base.snapshot != nil {
+		snapshot := *c.base.snapshot
+		p.snapshot = &snapshot
+	}
+	rules, snapshot := p.rules, p.snapshot
+	for _, opt := range opts {
+		opt.Apply(&p)
+	}
+	sameRules := len(p.rules) > 0 && &p.rules[0] == &rules[0]
+	if sameRules && p.snapshot == snapshot && p.subquery == c.base.subquery {
+		p.compiled = c
+		return &p
+	}
+	// columns resolved by Compile may not hold, e.g., under subquery, so they are resolved again by setup
+	if sameRules {
+		p.SetRules(c.rules...)
+	}
+	if p.snapshot == snapshot && c.snapshot != nil {
+		snapshot := *c.snapshot
+		p.snapshot = &snapshot
+	}
+	return &p
+}
+
+/* private */
+
+// prepare sets up paginator for dest of the model type without reflecting on model again
+func (c *Compiled) prepare(p *Paginator, dest interface{}) error {
+	if util.ReflectType(dest) != c.model {
+		return ErrInvalidModel
+	}
+	if err := p.validateSettings(); err != nil {
+		return err
+	}
+	for i := range p.rules {
+		if p.rules[i].Order == "" {
+			p.rules[i].Order = p.order
+		}
+	}
+	return nil
+}
+
+// newCursorCodec returns cached codec with cursor limits of paginator
+func (c *Compiled) newCursorCodec(p *Paginator) *cursorCodec {
+	codec := *c.codec
+	codec.limits = p.strictCursor
+	return &codec
+}
+
+var (
+	timeType   = reflect.TypeOf(time.Time{})
+	valuerType = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+)
+
+// isOrderableType reports whether values of type can be ordered by database and compared in cursors
+func isOrderableType(t reflect.Type) bool {
+	if t.Implements(valuerType) || reflect.PtrTo(t).Implements(valuerType) {
+		return true
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	k := t.Kind()
+	return t == timeType || k == reflect.String || k == reflect.Bool || isNumberKind(k)
+}
@@ -1,6 +1,57 @@
 package paginator
 
-import "github.com/hashicorp/gorm-cursor-paginator/cursor"
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/hashicorp/gorm-cursor-paginator/cursor"
+)
 
 // Cursor re-exports cursor.Cursor
 type Cursor = cursor.Cursor
+
+// cursorCodec encodes values of given types into cursor and decodes them back.
+// Values are carried by fields of a struct built at runtime, so that values not
+// coming from model fields (e.g., snapshot bound) can share the same cursor format.
+type cursorCodec struct {
+	model reflect.Type
+	keys  []string
+	// limits of strict decoding, nil when cursors are not decoded strictly
+	limits *cursor.Limits
+}
+
+func newCursorCodec(types []reflect.Type, limits *cursor.Limits) *cursorCodec {
+	fields := make([]reflect.StructField, len(types))
+	keys := make([]string, len(types))
+	for i, t := range types {
+		keys[i] = fmt.Sprintf("Value%d", i)
+		fields[i] = reflect.StructField{
+			Name: keys[i],
+			Type: t,
+		}
+	}
+	return &cursorCodec{
+		model:  reflect.StructOf(fields),
+		keys:   keys,
+		limits: limits,
+	}
+}
+
+func (c *cursorCodec) encode(values []interface{}) (string, error) {
+	model := reflect.New(c.model).Elem()
+	for i, v := range values {
+		// nil stands for zero value of nilable types
+		if v != nil {
+			model.Field(i).Set(reflect.ValueOf(v))
+		}
+	}
+	return cursor.NewEncoder(c.keys...).Encode(model.Interface())
+}
+
+func (c *cursorCodec) decode(s string) ([]interface{}, error) {
+	decoder := cursor.NewDecoder(c.keys...)
+	if c.limits != nil {
+		decoder = cursor.NewStrictDecoder(*c.limits, c.keys...)
+	}
+	return decoder.Decode(s, reflect.New(c.model).Interface())
+}
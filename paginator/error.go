@@ -1,12 +1,123 @@
 package paginator
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/hashicorp/gorm-cursor-paginator/cursor"
+)
 
 // Errors for paginator
 var (
-	ErrInvalidCursor = errors.New("invalid cursor for paginating")
-	ErrInvalidLimit  = errors.New("limit should be greater than 0")
-	ErrInvalidModel  = errors.New("model fields should match rules or keys specified for paginator")
-	ErrInvalidOrder  = errors.New("order should be ASC or DESC")
-	ErrNoRule        = errors.New("paginator should have at least one rule")
+	ErrInvalidAggregateRule = errors.New("aggregate rule should have SQLRepr specified")
+	ErrInvalidCheckpoint    = errors.New("checkpoint cursor cannot be decoded under current rules of paginator")
+	ErrInvalidCursor        = errors.New("invalid cursor for paginating")
+	ErrInvalidIndexRule     = errors.New("rules should only refer to columns of paginated model for building index")
+	ErrInvalidLimit         = errors.New("limit should be greater than 0")
+	ErrInvalidMergeRule     = errors.New("rules of merged sources should agree in one dialect and be comparable in Go as it orders them")
+	ErrInvalidModel         = errors.New("model fields should match rules or keys specified for paginator")
+	ErrInvalidOrder         = errors.New("order should be ASC or DESC")
+	ErrInvalidPageLinks     = errors.New("number of page links should be greater than 0")
+	ErrInvalidPartitions    = errors.New("number of partitions should be greater than 0")
+	ErrInvalidPollInterval  = errors.New("poll interval should be greater than 0")
+	ErrInvalidRuleValues    = errors.New("rule values should be strings or numbers matching type of key")
+	ErrInvalidShuffleRule   = errors.New("shuffle rule should be on non-nullable integer or string key")
+	ErrInvalidSliceRule     = errors.New("rules should be comparable in Go as slice dialect orders them for paging slices")
+	ErrNoRule               = errors.New("paginator should have at least one rule")
+	ErrUnorderableKey       = errors.New("key should be of orderable type, e.g., number, string, time or driver.Valuer")
+	ErrUnsupportedDialect   = errors.New("dialect is not supported by paginator")
+	ErrUnsupportedFormat    = errors.New("format is not supported by exporter")
+	ErrUnsupportedPartition = errors.New("rows cannot be partitioned under aggregate rules or before cursor")
 )
+
+// KeyError describes key of paginator failing validation against model,
+// it matches the sentinel in Err by errors.Is
+type KeyError struct {
+	Key string
+	// Type is Go type of key on model, nil when key is not found
+	Type reflect.Type
+	Err  error
+}
+
+func (e *KeyError) Error() string {
+	if e.Type == nil {
+		return fmt.Sprintf("%v: key %s not found", e.Err, e.Key)
+	}
+	return fmt.Sprintf("%v: key %s (%v)", e.Err, e.Key, e.Type)
+}
+
+// Unwrap returns sentinel of failure
+func (e *KeyError) Unwrap() error {
+	return e.Err
+}
+
+// CursorError describes why cursor cannot be decoded under rules of paginator,
+// it matches ErrInvalidCursor by errors.Is
+type CursorError struct {
+	// Key of the failing value, empty when cursor is malformed as a whole or value does not come
+	// from a key, e.g., seed of shuffle rules
+	Key string
+	// Type is Go type expected for the value
+	Type reflect.Type
+	// Position of the value in cursor, -1 when cursor is malformed as a whole
+	Position int
+	Cause    error
+}
+
+func (e *CursorError) Error() string {
+	cause := e.Cause
+	// failing value of decoding is told by e already, in terms of paginator
+	var de *cursor.DecodeError
+	if errors.As(cause, &de) && de.Cause != nil {
+		cause = de.Cause
+	}
+	switch {
+	case e.Position < 0:
+		return fmt.Sprintf("%s: %v", ErrInvalidCursor, cause)
+	case e.Key == "":
+		return fmt.Sprintf("%s: position %d (%v): %v", ErrInvalidCursor, e.Position, e.Type, cause)
+	}
+	return fmt.Sprintf("%s: key %s (%v) at position %d: %v", ErrInvalidCursor, e.Key, e.Type, e.Position, cause)
+}
+
+// Unwrap returns cause of failure, e.g., *cursor.DecodeError
+func (e *CursorError) Unwrap() error {
+	return e.Cause
+}
+
+// Is reports whether target is ErrInvalidCursor
+func (e *CursorError) Is(target error) bool {
+	return target == ErrInvalidCursor
+}
+
+// CheckpointError describes checkpoint of job which cannot be decoded under current rules of paginator,
+// it matches ErrInvalidCheckpoint by errors.Is, and its cause (e.g., *CursorError) by errors.As
+type CheckpointError struct {
+	Job   string
+	Cause error
+}
+
+func (e *CheckpointError) Error() string {
+	return fmt.Sprintf("%s: job %s: %v", ErrInvalidCheckpoint, e.Job, e.Cause)
+}
+
+// Unwrap returns cause of failure, e.g., *CursorError
+func (e *CheckpointError) Unwrap() error {
+	return e.Cause
+}
+
+// Is reports whether target is ErrInvalidCheckpoint
+func (e *CheckpointError) Is(target error) bool {
+	return target == ErrInvalidCheckpoint
+}
+
+// newCursorError returns CursorError of decoding failure, key names value at each position of cursor
+func newCursorError(err error, key func(position int) string) error {
+	e := &CursorError{Position: -1, Cause: err}
+	var de *cursor.DecodeError
+	if errors.As(err, &de) && de.Position >= 0 {
+		e.Key, e.Type, e.Position = key(de.Position), de.Type, de.Position
+	}
+	return e
+}
@@ -0,0 +1,132 @@
+package paginator
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/jinzhu/gorm"
+)
+
+// Plan query plan of paging statement reported by database
+type Plan struct {
+	// Details are lines of plan reported by EXPLAIN
+	Details []string
+	// Sort reports whether ORDER BY needs an extra sort step
+	Sort bool
+	// FullScan reports whether rows are read sequentially without index before sorting
+	FullScan bool
+}
+
+// IndexBacked reports whether ORDER BY is satisfied by an index
+func (p Plan) IndexBacked() bool {
+	return !p.Sort && !p.FullScan
+}
+
+// Explain runs EXPLAIN on paging statement and reports its query plan,
+// only sqlite3, postgres and mysql dialects are supported
+func (p *Paginator) Explain(db *gorm.DB, dest interface{}) (plan Plan, err error) {
+	fields, err := p.prepare(db, dest)
+	if err != nil {
+		return
+	}
+	stmt := p.appendPagingQuery(db, fields).Model(dest).QueryExpr()
+	switch db.Dialect().GetName() {
+	case "sqlite3":
+		return explain(db.New().Raw("EXPLAIN QUERY PLAN ?", stmt), parseSQLitePlan)
+	case "postgres":
+		return explain(db.New().Raw("EXPLAIN ?", stmt), parsePostgresPlan)
+	case "mysql":
+		return explain(db.New().Raw("EXPLAIN ?", stmt), parseMySQLPlan)
+	}
+	return Plan{}, ErrUnsupportedDialect
+}
+
+/* private */
+
+func explain(stmt *gorm.DB, parse func(rows []map[string]string) Plan) (plan Plan, err error) {
+	rows, err := queryRows(stmt)
+	if err != nil {
+		return
+	}
+	return parse(rows), nil
+}
+
+// queryRows runs stmt and returns rows keyed by lower case column names
+func queryRows(stmt *gorm.DB) (result []map[string]string, err error) {
+	rows, err := stmt.Rows()
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+	columns, err := rows.Columns()
+	if err != nil {
+		return
+	}
+	values := make([]sql.NullString, len(columns))
+	dest := make([]interface{}, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	for rows.Next() {
+		if err = rows.Scan(dest...); err != nil {
+			return
+		}
+		row := make(map[string]string, len(columns))
+		for i, column := range columns {
+			row[strings.ToLower(column)] = values[i].String
+		}
+		result = append(result, row)
+	}
+	err = rows.Err()
+	return
+}
+
+func parseSQLitePlan(rows []map[string]string) (plan Plan) {
+	scanWithoutIndex := false
+	for _, row := range rows {
+		detail := row["detail"]
+		plan.Details = append(plan.Details, detail)
+		if strings.Contains(detail, "TEMP B-TREE") && strings.Contains(detail, "ORDER BY") {
+			plan.Sort = true
+		}
+		if strings.HasPrefix(detail, "SCAN") && !strings.Contains(detail, " USING ") {
+			scanWithoutIndex = true
+		}
+	}
+	// scan without index walks table in rowid order,
+	// it only reads the whole table when rows need to be sorted afterwards
+	plan.FullScan = scanWithoutIndex && plan.Sort
+	return
+}
+
+func parsePostgresPlan(rows []map[string]string) (plan Plan) {
+	for _, row := range rows {
+		detail := row["query plan"]
+		plan.Details = append(plan.Details, detail)
+		node := strings.TrimLeft(detail, " ->")
+		if strings.HasPrefix(node, "Sort") || strings.HasPrefix(node, "Incremental Sort") {
+			plan.Sort = true
+		}
+		if strings.HasPrefix(node, "Seq Scan") {
+			plan.FullScan = true
+		}
+	}
+	return
+}
+
+func parseMySQLPlan(rows []map[string]string) (plan Plan) {
+	for _, row := range rows {
+		plan.Details = append(plan.Details, fmt.Sprintf(
+			"table=%s type=%s key=%s extra=%s",
+			row["table"], row["type"], row["key"], row["extra"],
+		))
+		if strings.Contains(row["extra"], "Using filesort") {
+			plan.Sort = true
+		}
+		if row["type"] == "ALL" {
+			plan.FullScan = true
+		}
+	}
+	return
+}
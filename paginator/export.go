@@ -0,0 +1,183 @@
+package paginator
+
+import (
+	"database/sql/driver"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// Format of exported rows
+type Format string
+
+// Formats
+const (
+	// JSONL writes a JSON object per line keyed by column names
+	JSONL Format = "jsonl"
+	// CSV writes a header of column names, then a line per row
+	CSV Format = "csv"
+)
+
+// Export walks all pages forward from after cursor and streams rows to w in format, columns are named
+// after gorm column names. dest is a pointer to slice of model, which is used as page buffer.
+//
+// checkpoint, if not nil, is called with after cursor of the last written row once a page is written
+// to w, an interrupted export can be resumed with WithAfter(cursor), and CSV header is only written
+// when there is no after cursor.
+func (p *Paginator) Export(
+	db *gorm.DB,
+	dest interface{},
+	w io.Writer,
+	format Format,
+	checkpoint func(after string) error,
+) error {
+	var e exporter
+	switch format {
+	case JSONL:
+		e = &jsonlExporter{w: w}
+	case CSV:
+		e = &csvExporter{w: csv.NewWriter(w)}
+	default:
+		return ErrUnsupportedFormat
+	}
+	columns := exportColumns(db, dest)
+	if p.cursor.After == nil {
+		if err := e.begin(columns); err != nil {
+			return err
+		}
+		if err := e.flush(); err != nil {
+			return err
+		}
+	}
+	return p.each(db, dest, func(after string) error {
+		elems := reflect.ValueOf(dest).Elem()
+		for i := 0; i < elems.Len(); i++ {
+			values, err := exportValues(db, elems.Index(i))
+			if err != nil {
+				return err
+			}
+			if err := e.write(columns, values); err != nil {
+				return err
+			}
+		}
+		if err := e.flush(); err != nil {
+			return err
+		}
+		if checkpoint == nil {
+			return nil
+		}
+		return checkpoint(after)
+	})
+}
+
+/* private */
+
+type exporter interface {
+	begin(columns []string) error
+	write(columns []string, values []interface{}) error
+	flush() error
+}
+
+type jsonlExporter struct {
+	w io.Writer
+}
+
+func (e *jsonlExporter) begin(columns []string) error {
+	return nil
+}
+
+func (e *jsonlExporter) write(columns []string, values []interface{}) error {
+	// encode object manually, so that keys are kept in column order
+	line := []byte{'{'}
+	for i, column := range columns {
+		if i > 0 {
+			line = append(line, ',')
+		}
+		key, err := json.Marshal(column)
+		if err != nil {
+			return err
+		}
+		value, err := json.Marshal(values[i])
+		if err != nil {
+			return err
+		}
+		line = append(append(append(line, key...), ':'), value...)
+	}
+	_, err := e.w.Write(append(line, '}', '\n'))
+	return err
+}
+
+func (e *jsonlExporter) flush() error {
+	return nil
+}
+
+type csvExporter struct {
+	w *csv.Writer
+}
+
+func (e *csvExporter) begin(columns []string) error {
+	return e.w.Write(columns)
+}
+
+func (e *csvExporter) write(columns []string, values []interface{}) error {
+	record := make([]string, len(values))
+	for i, v := range values {
+		switch v := v.(type) {
+		case nil:
+			record[i] = ""
+		case time.Time:
+			record[i] = v.Format(time.RFC3339Nano)
+		case []byte:
+			record[i] = string(v)
+		default:
+			record[i] = fmt.Sprint(v)
+		}
+	}
+	return e.w.Write(record)
+}
+
+func (e *csvExporter) flush() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+func isExportField(f *gorm.StructField) bool {
+	return f.IsNormal && !f.IsIgnored
+}
+
+func exportColumns(db *gorm.DB, dest interface{}) (columns []string) {
+	for _, f := range db.NewScope(dest).GetModelStruct().StructFields {
+		if isExportField(f) {
+			columns = append(columns, f.DBName)
+		}
+	}
+	return
+}
+
+// exportValues returns values of elem in the same order as exportColumns
+func exportValues(db *gorm.DB, elem reflect.Value) (values []interface{}, err error) {
+	for _, f := range db.NewScope(elem.Addr().Interface()).Fields() {
+		if !isExportField(f.StructField) {
+			continue
+		}
+		v := reflect.Indirect(f.Field)
+		if !v.IsValid() {
+			values = append(values, nil)
+			continue
+		}
+		value := v.Interface()
+		// e.g., sql.NullString
+		if valuer, ok := value.(driver.Valuer); ok {
+			if value, err = valuer.Value(); err != nil {
+				return
+			}
+		}
+		values = append(values, value)
+	}
+	return
+}
@@ -0,0 +1,149 @@
+package paginator
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// Follow keeps paginating forward from after cursor in ascending order, and calls fn whenever
+// new rows appear in dest. It polls database with poll interval, the interval doubles
+// up to max poll interval when there is no new row, and resets once new rows arrive.
+//
+// Rows committing late (e.g., auto increment IDs committed out of order) could fall behind
+// the cursor. With lag window of n, the last n delivered rows are scanned again on every poll,
+// so late rows among them are still delivered. Cursor passed to fn points to the start of
+// lag window, resuming from it may deliver up to n rows again but never misses late rows.
+//
+// Follow returns when ctx is done or fn returns an error, even while draining rows ready already.
+func (p *Paginator) Follow(ctx context.Context, db *gorm.DB, dest interface{}, fn func(c Cursor) error) error {
+	if p.pollInterval <= 0 {
+		return ErrInvalidPollInterval
+	}
+	fp := p.newFollowPaginator()
+	fields, err := fp.prepare(db, dest)
+	if err != nil {
+		return err
+	}
+	codec := fp.newCursorCodec(dest)
+	elems := reflect.ValueOf(dest).Elem()
+
+	after := fp.cursor.After
+	// window holds encoded cursors of delivered rows after cursor in ascending order
+	var window []string
+	maxInterval := p.maxPollInterval
+	if maxInterval < p.pollInterval {
+		maxInterval = p.pollInterval
+	}
+	interval := p.pollInterval
+	for {
+		// pages of backlog are fetched without waiting, so cancellation is checked before every page
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		// fields of initial cursor are decoded by prepare, later cursors are encoded by codec
+		if after != fp.cursor.After {
+			values, err := codec.decode(*after)
+			if err != nil {
+				return newCursorError(err, fp.cursorKey)
+			}
+			fields = values[:len(fp.rules)]
+			fp.cursor.After = after
+		}
+		// fetch rows in window again plus a page of new rows
+		fp.limit = p.limit + len(window)
+		rows := reflect.New(elems.Type())
+		if err := fp.appendPagingQuery(db, fields).Find(rows.Interface()).Error; err != nil {
+			return err
+		}
+		rows = rows.Elem()
+
+		seen := make(map[string]bool, len(window))
+		for _, c := range window {
+			seen[c] = true
+		}
+		fetched := make(map[string]bool, rows.Len())
+		known := make([]string, 0, rows.Len()+len(window))
+		delivered := reflect.MakeSlice(elems.Type(), 0, p.limit)
+		truncated := rows.Len() > fp.limit
+		for i := 0; i < rows.Len(); i++ {
+			c, err := codec.encode(fp.getCursorValues(rows.Index(i)))
+			if err != nil {
+				return err
+			}
+			if !seen[c] {
+				if delivered.Len() == p.limit {
+					truncated = true
+					break
+				}
+				delivered = reflect.Append(delivered, rows.Index(i))
+			}
+			fetched[c] = true
+			known = append(known, c)
+		}
+		numFetched := len(known)
+		// rows in window but not fetched are beyond fetched rows when truncated, otherwise they are deleted
+		if truncated {
+			for _, c := range window {
+				if !fetched[c] {
+					known = append(known, c)
+				}
+			}
+		}
+		// slide window over known rows, but never move cursor beyond fetched rows,
+		// since there may be rows not fetched yet in between
+		start := len(known) - p.lagWindow
+		if start > numFetched {
+			start = numFetched
+		}
+		if start > 0 {
+			after = &known[start-1]
+			window = known[start:]
+		} else {
+			window = known
+		}
+
+		if delivered.Len() > 0 {
+			elems.Set(delivered)
+			if err := fn(Cursor{After: after}); err != nil {
+				return err
+			}
+			interval = p.pollInterval
+		}
+		// there may be more rows ready, poll again immediately
+		if truncated {
+			continue
+		}
+		wait := interval
+		if delivered.Len() == 0 {
+			if interval *= 2; interval > maxInterval {
+				interval = maxInterval
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+/* private */
+
+// newFollowPaginator returns a copy of paginator paging forward from after cursor in ascending order
+func (p *Paginator) newFollowPaginator() *Paginator {
+	fp := *p
+	fp.SetRules(p.rules...)
+	for i := range fp.rules {
+		fp.rules[i].Order = ASC
+	}
+	fp.order = ASC
+	fp.cursor = Cursor{After: p.cursor.After}
+	if p.snapshot != nil {
+		snapshot := *p.snapshot
+		fp.snapshot = &snapshot
+	}
+	return &fp
+}
@@ -0,0 +1,109 @@
+package paginator
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/jinzhu/gorm"
+)
+
+// IndexSQL builds CREATE INDEX statement matching paging rules on model,
+// index name will be derived from table and columns when name is empty
+func (p *Paginator) IndexSQL(db *gorm.DB, model interface{}, name string) (string, error) {
+	columns, err := p.indexColumns(db, model)
+	if err != nil {
+		return "", err
+	}
+	if name == "" {
+		name = p.indexName(db.NewScope(model).TableName())
+	}
+	return buildIndexSQL(db, model, name, columns), nil
+}
+
+// AutoMigrateIndex auto migrates model and adds index matching paging rules on it,
+// index name will be derived from table and columns when name is empty
+func (p *Paginator) AutoMigrateIndex(db *gorm.DB, model interface{}, name string) error {
+	columns, err := p.indexColumns(db, model)
+	if err != nil {
+		return err
+	}
+	if err = db.AutoMigrate(model).Error; err != nil {
+		return err
+	}
+	table := db.NewScope(model).TableName()
+	if name == "" {
+		name = p.indexName(table)
+	}
+	// sqlite looks for index name in statement as it is written
+	if db.Dialect().HasIndex(table, name) || db.Dialect().HasIndex(table, db.Dialect().Quote(name)) {
+		return nil
+	}
+	return db.Exec(buildIndexSQL(db, model, name, columns)).Error
+}
+
+/* private */
+
+// identifierRegexp matches columns which are quoted in index, other columns are custom expressions
+var identifierRegexp = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+func buildIndexSQL(db *gorm.DB, model interface{}, name string, columns []string) string {
+	return fmt.Sprintf(
+		"CREATE INDEX %s ON %s (%s)",
+		db.Dialect().Quote(name),
+		db.NewScope(model).QuotedTableName(),
+		strings.Join(columns, ", "),
+	)
+}
+
+func (p *Paginator) indexColumns(db *gorm.DB, model interface{}) ([]string, error) {
+	if err := p.validate(model); err != nil {
+		return nil, err
+	}
+	p.setup(db, model)
+	table := db.NewScope(model).TableName()
+	columns := make([]string, len(p.rules))
+	for i, rule := range p.rules {
+		column := rule.SQLRepr
+		// index can only be built on columns of paginated table
+		if rule.Aggregate || len(rule.Values) > 0 || rule.Shuffle {
+			return nil, ErrInvalidIndexRule
+		} else if strings.HasPrefix(column, table+".") {
+			column = column[len(table)+1:]
+		} else if strings.Contains(column, ".") {
+			return nil, ErrInvalidIndexRule
+		}
+		// columns may be reserved words or mixed-case
+		if identifierRegexp.MatchString(column) {
+			column = db.Dialect().Quote(column)
+		}
+		// mysql indexes take collation of column
+		if rule.Collation != "" && db.Dialect().GetName() != "mysql" {
+			column = fmt.Sprintf("%s COLLATE %s", column, rule.Collation)
+		}
+		columns[i] = fmt.Sprintf("%s %s", column, rule.Order)
+		// postgres sorts nulls as larger than any value, index should keep the same placement
+		if db.Dialect().GetName() == "postgres" {
+			if rule.Order == ASC {
+				columns[i] += " NULLS LAST"
+			} else {
+				columns[i] += " NULLS FIRST"
+			}
+		}
+	}
+	return columns, nil
+}
+
+func (p *Paginator) indexName(table string) string {
+	name := "idx_" + table
+	for _, rule := range p.rules {
+		name += "_" + rule.SQLRepr[strings.LastIndex(rule.SQLRepr, ".")+1:]
+	}
+	// custom SQL representation may contain characters not allowed in identifier
+	return strings.Map(func(r rune) rune {
+		if r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, name)
+}
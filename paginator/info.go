@@ -0,0 +1,106 @@
+package paginator
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/jinzhu/gorm"
+)
+
+// PageInfo reports how many pages there are after current page in paging direction,
+// it is zero when look ahead is not configured
+type PageInfo struct {
+	// MorePages is number of pages after current page, at most look ahead
+	MorePages int
+	// MorePagesCapped reports whether there are even more pages than MorePages
+	MorePagesCapped bool
+}
+
+// PaginateWithInfo paginates data as Paginate does, then looks ahead for number of more pages
+// with a keys only query fetching up to look ahead × limit rows after current page
+func (p *Paginator) PaginateWithInfo(db *gorm.DB, dest interface{}) (result *gorm.DB, c Cursor, info PageInfo, err error) {
+	if result, c, err = p.Paginate(db, dest); err != nil || result.Error != nil {
+		return
+	}
+	info, err = p.lookAhead(db, dest, c)
+	return
+}
+
+// EstimateTotal returns number of rows of statement estimated by query planner without counting,
+// the value is approximate and could be far off when table statistics are stale.
+// Only postgres and mysql dialects are supported.
+func EstimateTotal(db *gorm.DB, dest interface{}) (int64, error) {
+	stmt := db.Model(dest).QueryExpr()
+	switch db.Dialect().GetName() {
+	case "postgres":
+		return estimatePostgresTotal(db.New().Raw("EXPLAIN (FORMAT JSON) ?", stmt))
+	case "mysql":
+		return estimateMySQLTotal(db.New().Raw("EXPLAIN ?", stmt))
+	}
+	return 0, ErrUnsupportedDialect
+}
+
+/* private */
+
+func (p *Paginator) lookAhead(db *gorm.DB, dest interface{}, c Cursor) (info PageInfo, err error) {
+	if p.lookAheadPages <= 0 {
+		return
+	}
+	// cursor pointing to the next page is only encoded when there are more rows
+	lp := *p
+	if p.isBackward() {
+		lp.cursor = Cursor{Before: c.Before}
+	} else {
+		lp.cursor = Cursor{After: c.After}
+	}
+	if lp.cursor.After == nil && lp.cursor.Before == nil {
+		return
+	}
+	lp.limit = p.lookAheadPages * p.limit
+	fields, err := lp.decodeCursor(dest)
+	if err != nil {
+		return
+	}
+	stmt := lp.appendKeysPagingQuery(db, fields).Model(dest).QueryExpr()
+	var n int
+	if err = newWrappingStmt(db).Raw("SELECT COUNT(*) FROM (?) AS look_ahead", stmt).Row().Scan(&n); err != nil {
+		return
+	}
+	if n > lp.limit {
+		n = lp.limit
+		info.MorePagesCapped = true
+	}
+	info.MorePages = (n + p.limit - 1) / p.limit
+	return
+}
+
+func estimatePostgresTotal(stmt *gorm.DB) (int64, error) {
+	var plan string
+	if err := stmt.Row().Scan(&plan); err != nil {
+		return 0, err
+	}
+	var nodes []struct {
+		Plan struct {
+			Rows float64 `json:"Plan Rows"`
+		}
+	}
+	if err := json.Unmarshal([]byte(plan), &nodes); err != nil {
+		return 0, err
+	}
+	if len(nodes) == 0 {
+		return 0, nil
+	}
+	return int64(nodes[0].Plan.Rows), nil
+}
+
+func estimateMySQLTotal(stmt *gorm.DB) (int64, error) {
+	rows, err := queryRows(stmt)
+	if err != nil {
+		return 0, err
+	}
+	// rows examined of the first table drives estimate of the whole statement
+	if len(rows) == 0 || rows[0]["rows"] == "" {
+		return 0, nil
+	}
+	return strconv.ParseInt(rows[0]["rows"], 10, 64)
+}
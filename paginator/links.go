@@ -0,0 +1,80 @@
+package paginator
+
+import (
+	"database/sql"
+	"reflect"
+
+	"github.com/jinzhu/gorm"
+)
+
+// PageLinks returns cursors starting each of next k pages after current page with a single
+// keys only query, so that clients can jump several pages ahead without OFFSET. The i-th
+// cursor starts the (i+1)-th page after current page, there are fewer than k cursors when
+// running out of rows. Cursors are after cursors, or before cursors when paginating backward.
+// k should be greater than 0.
+func (p *Paginator) PageLinks(db *gorm.DB, dest interface{}, k int) (links []string, err error) {
+	if k < 1 {
+		return nil, ErrInvalidPageLinks
+	}
+	fields, err := p.prepare(db, dest)
+	if err != nil {
+		return
+	}
+	lp := *p
+	lp.limit = k * p.limit
+	rows, err := lp.appendKeysPagingQuery(db, fields).Model(dest).Rows()
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+	codec := p.newCursorCodec(dest)
+	n := 0
+	for rows.Next() {
+		n++
+		// last row of each page bounds the next page
+		if n%p.limit != 0 {
+			continue
+		}
+		values, err := p.scanCursorValues(rows, dest)
+		if err != nil {
+			return nil, err
+		}
+		c, err := codec.encode(values)
+		if err != nil {
+			return nil, err
+		}
+		links = append(links, c)
+	}
+	if err = rows.Err(); err != nil {
+		return
+	}
+	// boundary only starts a page when there are rows after it
+	if pages := (n - 1) / p.limit; len(links) > pages {
+		links = links[:pages]
+	}
+	return
+}
+
+/* private */
+
+// scanCursorValues scans keys selected by appendKeysPagingQuery into cursor values
+func (p *Paginator) scanCursorValues(rows *sql.Rows, dest interface{}) ([]interface{}, error) {
+	ptrs := make([]interface{}, len(p.rules))
+	for i, rule := range p.rules {
+		ptrs[i] = reflect.New(rule.cursorType(dest)).Interface()
+	}
+	if err := rows.Scan(ptrs...); err != nil {
+		return nil, err
+	}
+	values := make([]interface{}, len(ptrs))
+	for i, ptr := range ptrs {
+		values[i] = reflect.ValueOf(ptr).Elem().Interface()
+	}
+	if p.snapshot != nil {
+		values = append(values, p.bound)
+	}
+	if p.hasShuffleRule() {
+		values = append(values, p.seed)
+	}
+	return values, nil
+}
@@ -0,0 +1,487 @@
+package paginator
+
+import (
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/gorm-cursor-paginator/cursor"
+	"github.com/jinzhu/gorm"
+)
+
+// PaginateShards paginates dest across shards in one global order of rules. Each shard is paged by
+// its own keyset query and rows are merged in Go, cursor holds position of every shard so that
+// the next page continues on all of them. Shards should be given in the same order on every page.
+func (p *Paginator) PaginateShards(shards []*gorm.DB, dest interface{}) (c Cursor, err error) {
+	sources := make([]*mergeSource, len(shards))
+	for i, db := range shards {
+		sources[i] = &mergeSource{
+			db:   db,
+			dest: reflect.New(reflect.TypeOf(dest).Elem()).Interface(),
+		}
+	}
+	picks, c, err := p.merge(sources)
+	if err != nil {
+		return
+	}
+	elems := reflect.ValueOf(dest).Elem()
+	result := reflect.MakeSlice(elems.Type(), 0, len(picks))
+	for _, pick := range picks {
+		result = reflect.Append(result, sources[pick.source].elems().Index(pick.index))
+	}
+	elems.Set(result)
+	return
+}
+
+/* private */
+
+// mergeSource is a statement paged by merge
+type mergeSource struct {
+	db   *gorm.DB
+	dest interface{}
+	// rules of source, rules of paginator are taken when it is nil
+	rules []Rule
+	// p pages source, it is set up by merge
+	p *Paginator
+	// keys are cursor values of rows in dest
+	keys [][]interface{}
+	more bool
+}
+
+func (s *mergeSource) elems() reflect.Value {
+	return reflect.ValueOf(s.dest).Elem()
+}
+
+// mergePick is a row of source picked into merged page
+type mergePick struct {
+	source int
+	index  int
+}
+
+// merge pages every source from its position in cursor and picks rows of merged page in order
+func (p *Paginator) merge(sources []*mergeSource) (picks []mergePick, c Cursor, err error) {
+	if err = p.validateMerge(sources); err != nil {
+		return
+	}
+	// sources share dialect, which places NULL in order
+	nullsFirst := len(sources) > 0 && sortsNullsFirst(sources[0].db.Dialect().GetName())
+	positions, err := p.decodeMergeCursor(len(sources))
+	if err != nil {
+		return
+	}
+	// all sources share seed of shuffle rules, even those starting without position
+	if p.hasShuffleRule() && p.seed == "" {
+		if p.seed, err = newSeed(); err != nil {
+			return
+		}
+	}
+	// sources are paged in direction of travel, which is reversed order for backward paging
+	for i, s := range sources {
+		s.p = p.newSourcePaginator(s.rules, positions[i])
+		var sc Cursor
+		if _, sc, err = s.p.Paginate(s.db, s.dest); err != nil {
+			return
+		}
+		s.more = sc.After != nil
+		elems := s.elems()
+		s.keys = make([][]interface{}, elems.Len())
+		for j := range s.keys {
+			s.keys[j] = s.p.getCursorValues(elems.Index(j))[:len(s.p.rules)]
+		}
+	}
+	picks = p.pickMerged(sources, nullsFirst)
+	if len(picks) == 0 {
+		return
+	}
+	hasMore := len(picks) < p.countRows(sources)
+	for _, s := range sources {
+		hasMore = hasMore || s.more
+	}
+	// end of page holds position of last picked row of every source, and start of page holds
+	// the first row of every source after its previous position, nil when there is none
+	ends := make([]*string, len(sources))
+	starts := make([]*string, len(sources))
+	copy(ends, positions)
+	picked := make([]int, len(sources))
+	for _, pick := range picks {
+		picked[pick.source] = pick.index + 1
+	}
+	for i, s := range sources {
+		if s.elems().Len() == 0 {
+			continue
+		}
+		if starts[i], err = s.p.encodeRow(s.dest, 0); err != nil {
+			return
+		}
+		if picked[i] > 0 {
+			if ends[i], err = s.p.encodeRow(s.dest, picked[i]-1); err != nil {
+				return
+			}
+		}
+	}
+	if p.isBackward() {
+		for i, j := 0, len(picks)-1; i < j; i, j = i+1, j-1 {
+			picks[i], picks[j] = picks[j], picks[i]
+		}
+		starts, ends = ends, starts
+	}
+	if p.isBackward() || hasMore {
+		if c.After, err = p.encodeMergeCursor(ends); err != nil {
+			return
+		}
+	}
+	if p.isForward() || (hasMore && p.isBackward()) {
+		if c.Before, err = p.encodeMergeCursor(starts); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// validateMerge validates rules of every source before any query, rules of sources should agree in
+// number and order, and rows should be comparable by them in Go as the dialect shared by sources orders them
+func (p *Paginator) validateMerge(sources []*mergeSource) error {
+	var first *Paginator
+	var classes []string
+	var dialect string
+	for i, s := range sources {
+		if i == 0 {
+			dialect = s.db.Dialect().GetName()
+		}
+		if s.db.Dialect().GetName() != dialect {
+			return ErrInvalidMergeRule
+		}
+		sp := p.newSourcePaginator(s.rules, nil)
+		if err := sp.validate(s.dest); err != nil {
+			return err
+		}
+		if first == nil {
+			first = sp
+			for _, rule := range sp.rules {
+				classes = append(classes, mergeClass(rule, s.dest, dialect))
+			}
+		}
+		if len(sp.rules) != len(first.rules) {
+			return ErrInvalidMergeRule
+		}
+		for i, rule := range sp.rules {
+			class := mergeClass(rule, s.dest, dialect)
+			if class == "" || class != classes[i] || rule.Order != first.rules[i].Order {
+				return ErrInvalidMergeRule
+			}
+		}
+	}
+	return nil
+}
+
+// pickMerged picks up to limit rows of sources in order of their rules, ties are broken by source
+func (p *Paginator) pickMerged(sources []*mergeSource, nullsFirst bool) (picks []mergePick) {
+	heads := make([]int, len(sources))
+	for len(picks) < p.limit {
+		next := -1
+		for i, s := range sources {
+			if heads[i] == len(s.keys) {
+				continue
+			}
+			if next == -1 {
+				next = i
+				continue
+			}
+			// ties are taken by the later source when travelling backward, so that pages agree
+			result := s.p.compareKeys(s.keys[heads[i]], sources[next].keys[heads[next]], nullsFirst)
+			if result < 0 || (result == 0 && p.isBackward()) {
+				next = i
+			}
+		}
+		if next == -1 {
+			return
+		}
+		picks = append(picks, mergePick{source: next, index: heads[next]})
+		heads[next]++
+	}
+	return
+}
+
+func (p *Paginator) countRows(sources []*mergeSource) (n int) {
+	for _, s := range sources {
+		n += len(s.keys)
+	}
+	return
+}
+
+// newSourcePaginator returns paginator paging a source forward from position in direction of travel,
+// rules of paginator are taken when rules is nil
+func (p *Paginator) newSourcePaginator(rules []Rule, position *string) *Paginator {
+	sp := *p
+	// pages of sources are not cached, as sources may share statement on different databases
+	sp.cache, sp.prefetch = nil, false
+	if rules == nil {
+		rules = p.rules
+	}
+	sp.SetRules(rules...)
+	sp.cursor = Cursor{After: position}
+	// rules of source may not be the compiled ones
+	if rules != nil {
+		sp.compiled = nil
+	}
+	for i := range sp.rules {
+		if sp.rules[i].Order == "" {
+			sp.rules[i].Order = p.order
+		}
+		if p.isBackward() {
+			sp.rules[i].Order = sp.rules[i].Order.flip()
+		}
+	}
+	if p.isBackward() {
+		sp.order = sp.order.flip()
+	}
+	return &sp
+}
+
+func (p *Paginator) encodeRow(dest interface{}, i int) (*string, error) {
+	elems := reflect.ValueOf(dest).Elem()
+	c, err := p.newCursorCodec(dest).encode(p.getCursorValues(elems.Index(i)))
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// newMergeCodec returns codec of cursor holding position of every source, and seed of shuffle rules
+func (p *Paginator) newMergeCodec(n int) *cursorCodec {
+	types := make([]reflect.Type, n)
+	for i := range types {
+		types[i] = reflect.TypeOf((*string)(nil))
+	}
+	if p.hasShuffleRule() {
+		types = append(types, reflect.TypeOf(""))
+	}
+	return newCursorCodec(types, p.getMergeLimits(len(types)))
+}
+
+// getMergeLimits returns limits of merge cursor of n elements, nil when cursors are not decoded strictly.
+// Elements are cursors of sources, which are decoded within limits of paginator later, so merge cursor
+// is limited by length of n source cursors instead.
+func (p *Paginator) getMergeLimits(n int) *cursor.Limits {
+	if p.strictCursor == nil {
+		return nil
+	}
+	limits := cursor.Limits{
+		MaxStringSize: p.strictCursor.MaxLength,
+		MaxDepth:      p.strictCursor.MaxDepth,
+	}
+	if length := p.strictCursor.MaxLength; length > 0 {
+		// json array of n quoted source cursors separated by commas, encoded in base64
+		size := 2 + n*(length+3)
+		limits.MaxLength = (size + 2) / 3 * 4
+	}
+	return &limits
+}
+
+func (p *Paginator) encodeMergeCursor(positions []*string) (*string, error) {
+	values := make([]interface{}, len(positions))
+	for i, position := range positions {
+		values[i] = position
+	}
+	if p.hasShuffleRule() {
+		values = append(values, p.seed)
+	}
+	c, err := p.newMergeCodec(len(positions)).encode(values)
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (p *Paginator) decodeMergeCursor(n int) ([]*string, error) {
+	positions := make([]*string, n)
+	var c *string
+	if p.isForward() {
+		c = p.cursor.After
+	} else if p.isBackward() {
+		c = p.cursor.Before
+	}
+	if c == nil {
+		return positions, nil
+	}
+	values, err := p.newMergeCodec(n).decode(*c)
+	if err != nil {
+		// positions of merge cursor are sources instead of keys
+		return nil, newCursorError(err, func(int) string { return "" })
+	}
+	for i := range positions {
+		positions[i] = values[i].(*string)
+	}
+	if p.hasShuffleRule() {
+		if err = p.decodeSeed(values[n], n); err != nil {
+			return nil, err
+		}
+	}
+	return positions, nil
+}
+
+/* compare */
+
+// compareKeys compares cursor values of rows by rules, nil is smaller than any value when nullsFirst,
+// and larger otherwise
+func (p *Paginator) compareKeys(a, b []interface{}, nullsFirst bool) int {
+	for i, rule := range p.rules {
+		result := compareValues(reflect.ValueOf(a[i]), reflect.ValueOf(b[i]), nullsFirst)
+		if rule.Order == DESC {
+			result = -result
+		}
+		if result != 0 {
+			return result
+		}
+	}
+	return 0
+}
+
+func compareValues(a, b reflect.Value, nullsFirst bool) int {
+	a, b = indirectValue(a), indirectValue(b)
+	nulls := 1
+	if nullsFirst {
+		nulls = -1
+	}
+	switch {
+	case !a.IsValid() && !b.IsValid():
+		return 0
+	case !a.IsValid():
+		return nulls
+	case !b.IsValid():
+		return -nulls
+	}
+	// values of the same merge class may still differ in kind across sources
+	switch ka, kb := a.Kind(), b.Kind(); {
+	case ka == reflect.String:
+		return strings.Compare(a.String(), b.String())
+	case ka == reflect.Bool:
+		return compareInts(boolInt(a.Bool()), boolInt(b.Bool()))
+	case isIntKind(ka) && isIntKind(kb):
+		return compareInts(a.Int(), b.Int())
+	case isUintKind(ka) && isUintKind(kb):
+		switch {
+		case a.Uint() < b.Uint():
+			return -1
+		case a.Uint() > b.Uint():
+			return 1
+		}
+		return 0
+	case isNumberKind(ka):
+		fa, fb := floatValue(a), floatValue(b)
+		switch {
+		case fa < fb:
+			return -1
+		case fa > fb:
+			return 1
+		}
+		return 0
+	}
+	ta, tb := a.Interface().(time.Time), b.Interface().(time.Time)
+	switch {
+	case ta.Before(tb):
+		return -1
+	case ta.After(tb):
+		return 1
+	}
+	return 0
+}
+
+func compareInts(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func isIntKind(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Int64
+}
+
+func isUintKind(k reflect.Kind) bool {
+	return k >= reflect.Uint && k <= reflect.Uint64
+}
+
+func floatValue(v reflect.Value) float64 {
+	switch k := v.Kind(); {
+	case isIntKind(k):
+		return float64(v.Int())
+	case isUintKind(k):
+		return float64(v.Uint())
+	}
+	return v.Float()
+}
+
+// sortsNullsFirst reports whether dialect orders NULL before any value in ascending order,
+// postgres orders it after any value while mysql and sqlite order it before
+func sortsNullsFirst(dialect string) bool {
+	return dialect != "postgres"
+}
+
+func boolInt(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// indirectValue dereferences pointers, invalid value stands for nil
+func indirectValue(v reflect.Value) reflect.Value {
+	for v.IsValid() && v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+// mergeClass returns class of values compared by rule in Go, rows of sources can only be merged by
+// rules of the same class. Empty class means values cannot be compared in Go as dialect orders them,
+// e.g., strings are only compared under collations known to order them by bytes.
+func mergeClass(rule Rule, dest interface{}, dialect string) string {
+	if rule.Collation != "" && !isByteOrderCollation(dialect, rule.Collation) {
+		return ""
+	}
+	t := rule.cursorType(dest)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch k := t.Kind(); {
+	case k == reflect.String:
+		// hashes of shuffle rules are digits ordered alike by any collation, and ties of hashes are
+		// md5 collisions on postgres and mysql, as sqlite only shuffles integer keys
+		if rule.Shuffle || rule.tieBreaker || isByteOrderCollation(dialect, rule.Collation) {
+			return "string"
+		}
+		return ""
+	case k == reflect.Bool:
+		return "bool"
+	case isNumberKind(k):
+		return "number"
+	case t == reflect.TypeOf(time.Time{}):
+		return "time"
+	}
+	return ""
+}
+
+// isByteOrderCollation reports whether collation of dialect orders strings by bytes as Go does,
+// empty collation stands for the default one
+func isByteOrderCollation(dialect, collation string) bool {
+	switch dialect {
+	case "postgres":
+		switch collation {
+		case "C", `"C"`, "POSIX", `"POSIX"`:
+			return true
+		}
+	case "mysql":
+		return strings.Trim(collation, "`") == "utf8mb4_0900_bin"
+	case "sqlite3":
+		// sqlite compares strings by memcmp unless told otherwise
+		return collation == "" || strings.EqualFold(collation, "BINARY")
+	}
+	return false
+}
@@ -1,9 +1,16 @@
 package paginator
 
+import (
+	"time"
+
+	"github.com/hashicorp/gorm-cursor-paginator/cursor"
+)
+
 var defaultConfig = Config{
-	Keys:  []string{"ID"},
-	Limit: 10,
-	Order: DESC,
+	Keys:         []string{"ID"},
+	Limit:        10,
+	Order:        DESC,
+	PollInterval: time.Second,
 }
 
 // Option for paginator
@@ -13,12 +20,31 @@ type Option interface {
 
 // Config for paginator
 type Config struct {
-	Rules  []Rule
-	Keys   []string
-	Limit  int
-	Order  Order
-	After  string
-	Before string
+	Rules     []Rule
+	Keys      []string
+	Limit     int
+	Order     Order
+	After     string
+	Before    string
+	Until     string
+	Snapshot  string
+	Seed      string
+	Subquery  bool
+	LookAhead int
+	Throttle  time.Duration
+	Cache     Cache
+	CacheTTL  time.Duration
+	Prefetch  bool
+
+	// SliceDialect is dialect whose order PaginateSlice follows, postgres when empty
+	SliceDialect string
+
+	// StrictCursor decodes cursors strictly within limits when it is not nil
+	StrictCursor *cursor.Limits
+
+	PollInterval    time.Duration
+	MaxPollInterval time.Duration
+	LagWindow       int
 }
 
 // Apply applies config to paginator
@@ -42,6 +68,45 @@ func (c *Config) Apply(p *Paginator) {
 	if c.Before != "" {
 		p.SetBeforeCursor(c.Before)
 	}
+	if c.Until != "" {
+		p.SetUntil(c.Until)
+	}
+	if c.StrictCursor != nil {
+		p.SetStrictCursor(*c.StrictCursor)
+	}
+	if c.Snapshot != "" {
+		p.SetSnapshot(c.Snapshot)
+	}
+	if c.Seed != "" {
+		p.SetSeed(c.Seed)
+	}
+	if c.Subquery {
+		p.SetSubquery(c.Subquery)
+	}
+	if c.LookAhead != 0 {
+		p.SetLookAhead(c.LookAhead)
+	}
+	if c.Throttle != 0 {
+		p.SetThrottle(c.Throttle)
+	}
+	if c.Cache != nil {
+		p.SetCache(c.Cache, c.CacheTTL)
+	}
+	if c.Prefetch {
+		p.SetPrefetch(c.Prefetch)
+	}
+	if c.SliceDialect != "" {
+		p.SetSliceDialect(c.SliceDialect)
+	}
+	if c.PollInterval != 0 {
+		p.SetPollInterval(c.PollInterval)
+	}
+	if c.MaxPollInterval != 0 {
+		p.SetMaxPollInterval(c.MaxPollInterval)
+	}
+	if c.LagWindow != 0 {
+		p.SetLagWindow(c.LagWindow)
+	}
 }
 
 // WithRules configures rules for paginator
@@ -85,3 +150,95 @@ func WithBefore(c string) Option {
 		Before: c,
 	}
 }
+
+// WithUntil configures inclusive upper bound cursor for paginator
+func WithUntil(c string) Option {
+	return &Config{
+		Until: c,
+	}
+}
+
+// WithSnapshot configures snapshot key for paginator
+func WithSnapshot(key string) Option {
+	return &Config{
+		Snapshot: key,
+	}
+}
+
+// WithStrictCursor configures strict decoding of cursors within limits for paginator
+func WithStrictCursor(limits cursor.Limits) Option {
+	return &Config{
+		StrictCursor: &limits,
+	}
+}
+
+// WithSeed configures seed of shuffle rules for paginator
+func WithSeed(seed string) Option {
+	return &Config{
+		Seed: seed,
+	}
+}
+
+// WithSubquery configures subquery mode for paginator
+func WithSubquery(subquery bool) Option {
+	return &Config{
+		Subquery: subquery,
+	}
+}
+
+// WithLookAhead configures max number of more pages counted by PaginateWithInfo
+func WithLookAhead(pages int) Option {
+	return &Config{
+		LookAhead: pages,
+	}
+}
+
+// WithThrottle configures delay between batches of EachBatch for paginator
+func WithThrottle(throttle time.Duration) Option {
+	return &Config{
+		Throttle: throttle,
+	}
+}
+
+// WithCache configures cache of pages and its ttl for paginator
+func WithCache(cache Cache, ttl time.Duration) Option {
+	return &Config{
+		Cache:    cache,
+		CacheTTL: ttl,
+	}
+}
+
+// WithPrefetch configures prefetching of the next page into cache for paginator
+func WithPrefetch(prefetch bool) Option {
+	return &Config{
+		Prefetch: prefetch,
+	}
+}
+
+// WithPollInterval configures poll interval of follow mode for paginator
+func WithPollInterval(interval time.Duration) Option {
+	return &Config{
+		PollInterval: interval,
+	}
+}
+
+// WithMaxPollInterval configures max poll interval of follow mode for paginator
+func WithMaxPollInterval(interval time.Duration) Option {
+	return &Config{
+		MaxPollInterval: interval,
+	}
+}
+
+// WithLagWindow configures lag window of follow mode for paginator
+func WithLagWindow(n int) Option {
+	return &Config{
+		LagWindow: n,
+	}
+}
+
+// WithSliceDialect configures dialect whose order PaginateSlice follows for paginator
+func WithSliceDialect(dialect string) Option {
+	return &Config{
+		SliceDialect: dialect,
+	}
+}
@@ -1,9 +1,11 @@
 package paginator
 
 import (
+	"database/sql"
 	"fmt"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/iancoleman/strcase"
 	"github.com/jinzhu/gorm"
@@ -21,18 +23,55 @@ func New(opts ...Option) *Paginator {
 	return p
 }
 
+// Interface for paginator, it is implemented by Paginator and paginatortest.Fake
+type Interface interface {
+	Paginate(db *gorm.DB, dest interface{}) (result *gorm.DB, c Cursor, err error)
+}
+
 // Paginator a builder doing pagination
 type Paginator struct {
-	cursor Cursor
-	rules  []Rule
-	limit  int
-	order  Order
+	cursor   Cursor
+	rules    []Rule
+	limit    int
+	order    Order
+	snapshot *Rule
+	subquery bool
+	// lookAheadPages is max number of more pages counted by PaginateWithInfo
+	lookAheadPages int
+	// seed of shuffle rules, it is carried in cursors
+	seed string
+	// throttle is delay between batches of EachBatch
+	throttle time.Duration
+	cache    Cache
+	cacheTTL time.Duration
+	prefetch bool
+	// bound is a pointer to upper bound value of snapshot key, nil when table is empty
+	bound interface{}
+	// strictCursor is limits of decoding cursors strictly, nil when cursors are not decoded strictly
+	strictCursor *cursor.Limits
+	// until is an inclusive upper bound cursor in paging order
+	until       *string
+	untilFields []interface{}
+	// compiled is set when paginator is created by Compiled with its rules
+	compiled *Compiled
+	// sliceDialect is dialect whose order PaginateSlice follows, postgres when empty
+	sliceDialect string
+	// follow mode
+	pollInterval    time.Duration
+	maxPollInterval time.Duration
+	lagWindow       int
 }
 
-// SetRules sets paging rules
+// SetRules sets paging rules, shuffle rules are followed by a rule on the same key breaking ties of hashes
 func (p *Paginator) SetRules(rules ...Rule) {
-	p.rules = make([]Rule, len(rules))
-	copy(p.rules, rules)
+	p.rules = make([]Rule, 0, len(rules))
+	for i, rule := range rules {
+		p.rules = append(p.rules, rule)
+		// rules copied from paginator have tie breakers already
+		if rule.Shuffle && (i+1 == len(rules) || !rules[i+1].tieBreaker) {
+			p.rules = append(p.rules, rule.newTieBreaker())
+		}
+	}
 }
 
 // SetKeys sets paging keys
@@ -66,16 +105,100 @@ func (p *Paginator) SetBeforeCursor(beforeCursor string) {
 	p.cursor.Before = &beforeCursor
 }
 
+// SetUntil sets inclusive upper bound cursor in paging order, rows after it are never paged,
+// e.g., after cursor of range returned by Partition
+func (p *Paginator) SetUntil(untilCursor string) {
+	p.until = &untilCursor
+}
+
+// SetStrictCursor sets cursors to be decoded strictly within limits, i.e., cursors should have exactly
+// the values of paginator, which is suggested for cursors from untrusted input. Limits are of a single cursor,
+// cursors of PaginateShards hold one cursor per shard and are limited to as many times MaxLength.
+func (p *Paginator) SetStrictCursor(limits cursor.Limits) {
+	p.strictCursor = &limits
+}
+
+// SetSeed sets seed of shuffle rules for the first page, later pages take seed from cursor.
+// A random seed is generated when it is empty, otherwise seed is hashed into format of generated seeds.
+func (p *Paginator) SetSeed(seed string) {
+	p.seed = ""
+	if seed != "" {
+		p.seed = formatSeed(seed)
+	}
+}
+
+// SetSliceDialect sets dialect whose order PaginateSlice follows, e.g., "postgres", "mysql" or "sqlite3",
+// so that cursors are interchangeable with Paginate on databases of that dialect. It is postgres when empty.
+func (p *Paginator) SetSliceDialect(dialect string) {
+	p.sliceDialect = dialect
+}
+
+// SetSnapshot sets snapshot key, the max value of key is recorded on the first page and
+// embedded in cursors, later pages will only see rows whose key is not greater than it
+func (p *Paginator) SetSnapshot(key string) {
+	p.snapshot = &Rule{
+		Key: key,
+	}
+}
+
+// SetSubquery sets whether to wrap statement as a derived table "page_src" for paging,
+// so that raw, UNION, DISTINCT or window function statements can be paginated as well.
+// Statement should specify its table itself, e.g., db.Raw(...), db.Table(...) or db.Model(...).
+func (p *Paginator) SetSubquery(subquery bool) {
+	p.subquery = subquery
+}
+
+// SetLookAhead sets max number of pages after current page counted by PaginateWithInfo
+func (p *Paginator) SetLookAhead(pages int) {
+	p.lookAheadPages = pages
+}
+
+// SetThrottle sets delay between batches of EachBatch
+func (p *Paginator) SetThrottle(throttle time.Duration) {
+	p.throttle = throttle
+}
+
+// SetCache sets cache of pages, pages are cached for ttl, or until evicted when ttl is 0
+func (p *Paginator) SetCache(cache Cache, ttl time.Duration) {
+	p.cache = cache
+	p.cacheTTL = ttl
+}
+
+// SetPrefetch sets whether to fetch the next page into cache in background once a page is served
+func (p *Paginator) SetPrefetch(prefetch bool) {
+	p.prefetch = prefetch
+}
+
+// SetPollInterval sets interval of polling for new rows in follow mode
+func (p *Paginator) SetPollInterval(interval time.Duration) {
+	p.pollInterval = interval
+}
+
+// SetMaxPollInterval sets max interval that polling backs off to when there is no new row
+func (p *Paginator) SetMaxPollInterval(interval time.Duration) {
+	p.maxPollInterval = interval
+}
+
+// SetLagWindow sets number of delivered rows scanned again in follow mode for late rows
+func (p *Paginator) SetLagWindow(n int) {
+	p.lagWindow = n
+}
+
 // Paginate paginates data
 func (p *Paginator) Paginate(db *gorm.DB, dest interface{}) (result *gorm.DB, c Cursor, err error) {
-	if err = p.validate(dest); err != nil {
-		return
-	}
-	p.setup(db, dest)
-	fields, err := p.decodeCursor(dest)
+	fields, err := p.prepare(db, dest)
 	if err != nil {
 		return
 	}
+	if p.cache != nil {
+		return p.paginateCached(db, dest, fields)
+	}
+	return p.paginate(db, dest, fields)
+}
+
+/* private */
+
+func (p *Paginator) paginate(db *gorm.DB, dest interface{}, fields []interface{}) (result *gorm.DB, c Cursor, err error) {
 	if result = p.appendPagingQuery(db, fields).Find(dest); result.Error != nil {
 		return
 	}
@@ -97,16 +220,89 @@ func (p *Paginator) Paginate(db *gorm.DB, dest interface{}) (result *gorm.DB, c
 	return
 }
 
-/* private */
+// prepare validates and sets up paginator for dest, returns decoded fields of cursor
+func (p *Paginator) prepare(db *gorm.DB, dest interface{}) (fields []interface{}, err error) {
+	if p.compiled != nil {
+		if err = p.compiled.prepare(p, dest); err != nil {
+			return
+		}
+	} else {
+		if err = p.validate(dest); err != nil {
+			return
+		}
+		p.setup(db, dest)
+	}
+	if fields, err = p.decodeCursor(dest); err != nil {
+		return
+	}
+	if p.until != nil {
+		if p.untilFields, err = p.decodeUntil(dest); err != nil {
+			return
+		}
+	}
+	if err = p.setupShuffle(db, dest); err != nil {
+		return
+	}
+	if p.snapshot != nil && fields == nil {
+		err = p.querySnapshotBound(db, dest)
+	}
+	return
+}
+
+// each walks pages forward from after cursor, and calls fn with cursor of the last row once dest is
+// filled by a page, empty pages are skipped
+func (p *Paginator) each(db *gorm.DB, dest interface{}, fn func(after string) error) error {
+	wp := p.newWalker()
+	for {
+		after, more, err := wp.walk(db, dest)
+		if err != nil || after == nil {
+			return err
+		}
+		if err = fn(*after); err != nil {
+			return err
+		}
+		if !more {
+			return nil
+		}
+	}
+}
+
+// newWalker returns a copy of paginator walking pages forward from after cursor
+func (p *Paginator) newWalker() *Paginator {
+	wp := *p
+	wp.SetRules(p.rules...)
+	wp.cursor = Cursor{After: p.cursor.After}
+	return &wp
+}
+
+// walk paginates a page into dest and moves after cursor to the last row of page,
+// after is nil for empty page
+func (p *Paginator) walk(db *gorm.DB, dest interface{}) (after *string, more bool, err error) {
+	result, c, err := p.Paginate(db, dest)
+	if err != nil {
+		return
+	}
+	if err = result.Error; err != nil {
+		return
+	}
+	elems := reflect.ValueOf(dest).Elem()
+	if elems.Kind() != reflect.Slice || elems.Len() == 0 {
+		return
+	}
+	// after cursor is not encoded for the last page
+	last, err := p.newCursorCodec(dest).encode(p.getCursorValues(elems.Index(elems.Len() - 1)))
+	if err != nil {
+		return
+	}
+	p.cursor.After = &last
+	return &last, c.After != nil, nil
+}
 
 func (p *Paginator) validate(dest interface{}) (err error) {
 	if len(p.rules) == 0 {
 		return ErrNoRule
 	}
-	if p.limit <= 0 {
-		return ErrInvalidLimit
-	}
-	if err = p.order.validate(); err != nil {
+	if err = p.validateSettings(); err != nil {
 		return
 	}
 	for _, rule := range p.rules {
@@ -114,32 +310,55 @@ func (p *Paginator) validate(dest interface{}) (err error) {
 			return
 		}
 	}
+	if p.snapshot != nil {
+		if err = p.snapshot.validate(dest); err != nil {
+			return
+		}
+	}
 	return
 }
 
+// validateSettings validates settings of paginator not depending on model
+func (p *Paginator) validateSettings() error {
+	if p.limit <= 0 {
+		return ErrInvalidLimit
+	}
+	return p.order.validate()
+}
+
 func (p *Paginator) setup(db *gorm.DB, dest interface{}) {
 	var sqlTable string
 	for i := range p.rules {
-		multiKey := strings.Contains(p.rules[i].Key, ".")
 		if p.rules[i].SQLRepr == "" {
-			// if key has levels then recalculate table name regardless prev value
-			// because it can be different for different keys in an aggregated model
-			if multiKey {
-				subkeys := strings.Split(p.rules[i].Key, ".")
-				parentPath := strings.Join(subkeys[0:len(subkeys)-1], ".")
-				if parent, ok := util.ReflectFieldByPath(dest, parentPath); ok {
-					sqlTable = db.NewScope(reflect.New(parent.Type).Interface()).TableName()
-				}
-			} else if sqlTable == "" {
-				sqlTable = db.NewScope(dest).TableName()
-			}
-			sqlKey := p.parseSQLKey(dest, p.rules[i].Key)
-			p.rules[i].SQLRepr = fmt.Sprintf("%s.%s", sqlTable, sqlKey)
+			p.rules[i].SQLRepr = p.buildSQLRepr(db, dest, p.rules[i].Key, &sqlTable)
 		}
 		if p.rules[i].Order == "" {
 			p.rules[i].Order = p.order
 		}
 	}
+	if p.snapshot != nil && p.snapshot.SQLRepr == "" {
+		p.snapshot.SQLRepr = p.buildSQLRepr(db, dest, p.snapshot.Key, &sqlTable)
+	}
+}
+
+func (p *Paginator) buildSQLRepr(db *gorm.DB, dest interface{}, key string, sqlTable *string) string {
+	// columns of derived table are not qualified by tables of statement
+	if p.subquery {
+		return fmt.Sprintf("%s.%s", subqueryAlias, p.parseSQLKey(dest, key))
+	}
+	// if key has levels then recalculate table name regardless prev value
+	// because it can be different for different keys in an aggregated model
+	if strings.Contains(key, ".") {
+		subkeys := strings.Split(key, ".")
+		parentPath := strings.Join(subkeys[0:len(subkeys)-1], ".")
+		if parent, ok := util.ReflectFieldByPath(dest, parentPath); ok {
+			*sqlTable = db.NewScope(reflect.New(parent.Type).Interface()).TableName()
+		}
+	} else if *sqlTable == "" {
+		*sqlTable = db.NewScope(dest).TableName()
+	}
+	sqlKey := p.parseSQLKey(dest, key)
+	return fmt.Sprintf("%s.%s", *sqlTable, sqlKey)
 }
 
 func (p *Paginator) parseSQLKey(dest interface{}, key string) string {
@@ -163,16 +382,76 @@ func (p *Paginator) parseSQLKey(dest interface{}, key string) string {
 }
 
 func (p *Paginator) decodeCursor(dest interface{}) (result []interface{}, err error) {
+	var c *string
 	if p.isForward() {
-		if result, err = cursor.NewDecoder(p.getKeys()...).Decode(*p.cursor.After, dest); err != nil {
-			err = ErrInvalidCursor
-		}
+		c = p.cursor.After
 	} else if p.isBackward() {
-		if result, err = cursor.NewDecoder(p.getKeys()...).Decode(*p.cursor.Before, dest); err != nil {
-			err = ErrInvalidCursor
+		c = p.cursor.Before
+	}
+	if c == nil {
+		return
+	}
+	if result, err = p.newCursorCodec(dest).decode(*c); err != nil {
+		return nil, newCursorError(err, p.cursorKey)
+	}
+	// snapshot bound and shuffle seed are carried after values of paging keys
+	extras := result[len(p.rules):]
+	if p.snapshot != nil {
+		p.bound, extras = extras[0], extras[1:]
+	}
+	if p.hasShuffleRule() {
+		if err = p.decodeSeed(extras[0], len(result)-1); err != nil {
+			return nil, err
 		}
 	}
-	return
+	return result[:len(p.rules)], nil
+}
+
+func (p *Paginator) decodeUntil(dest interface{}) ([]interface{}, error) {
+	result, err := p.newCursorCodec(dest).decode(*p.until)
+	if err != nil {
+		return nil, newCursorError(err, p.cursorKey)
+	}
+	return result[:len(p.rules)], nil
+}
+
+// cursorKey returns key of value at position of cursor, empty for seed of shuffle rules
+func (p *Paginator) cursorKey(position int) string {
+	switch {
+	case position < len(p.rules):
+		return p.rules[position].Key
+	case p.snapshot != nil && position == len(p.rules):
+		return p.snapshot.Key
+	}
+	return ""
+}
+
+func (p *Paginator) querySnapshotBound(db *gorm.DB, dest interface{}) error {
+	bound := reflect.New(p.getSnapshotBoundType(dest))
+	// take bound from the row with max value instead of MAX(), so that column type can be preserved
+	var stmt *gorm.DB
+	if p.subquery {
+		stmt = newWrappingStmt(db).Raw(
+			fmt.Sprintf(
+				"SELECT %[1]s FROM (?) AS %[2]s WHERE %[1]s IS NOT NULL ORDER BY %[1]s DESC LIMIT 1",
+				p.snapshot.SQLRepr,
+				subqueryAlias,
+			),
+			db.QueryExpr(),
+		)
+	} else {
+		stmt = db.Model(dest).
+			Select(p.snapshot.SQLRepr).
+			Where(fmt.Sprintf("%s IS NOT NULL", p.snapshot.SQLRepr)).
+			Order(fmt.Sprintf("%s DESC", p.snapshot.SQLRepr), true).
+			Limit(1)
+	}
+	err := stmt.Row().Scan(bound.Interface())
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	p.bound = bound.Elem().Interface()
+	return nil
 }
 
 func (p *Paginator) isForward() bool {
@@ -185,28 +464,82 @@ func (p *Paginator) isBackward() bool {
 }
 
 func (p *Paginator) appendPagingQuery(db *gorm.DB, fields []interface{}) *gorm.DB {
+	if p.subquery {
+		return p.appendWrappedPagingQuery(db, fields, "*", nil)
+	}
 	stmt := db
 	stmt = stmt.Limit(p.limit + 1)
-	stmt = stmt.Order(p.buildOrderSQL())
+	order, orderArgs := p.buildOrderSQL()
+	stmt = stmt.Order(gorm.Expr(order, orderArgs...))
+	return p.appendPagingFilters(stmt, fields)
+}
+
+// appendPagingFilters filters statement by cursor, snapshot bound and until cursor
+func (p *Paginator) appendPagingFilters(stmt *gorm.DB, fields []interface{}) *gorm.DB {
 	if len(fields) > 0 {
-		stmt = stmt.Where(
-			p.buildCursorSQLQuery(),
-			p.buildCursorSQLQueryArgs(fields)...,
+		stmt = p.filter(stmt, p.buildCursorSQLQuery(), p.buildCursorSQLQueryArgs(fields)...)
+	}
+	if p.snapshot != nil {
+		if bound := reflect.ValueOf(p.bound); !bound.IsNil() {
+			stmt = p.filter(
+				stmt,
+				fmt.Sprintf("%s <= ?", p.snapshot.SQLRepr),
+				bound.Elem().Interface(),
+			)
+		}
+	}
+	if p.untilFields != nil {
+		stmt = p.filter(
+			stmt,
+			p.buildUntilSQLQuery(),
+			p.buildUntilSQLQueryArgs(p.untilFields)...,
 		)
 	}
 	return stmt
 }
 
-func (p *Paginator) buildOrderSQL() string {
+// filter adds paging condition to statement, aggregates can only be filtered after grouping
+func (p *Paginator) filter(stmt *gorm.DB, query string, args ...interface{}) *gorm.DB {
+	if p.hasAggregateRule() {
+		return stmt.Having(query, args...)
+	}
+	return stmt.Where(query, args...)
+}
+
+// appendKeysPagingQuery is appendPagingQuery selecting only paging keys, aliased as key0, key1, ...
+func (p *Paginator) appendKeysPagingQuery(db *gorm.DB, fields []interface{}) *gorm.DB {
+	columns, args := p.buildKeysSQL()
+	if p.subquery {
+		return p.appendWrappedPagingQuery(db, fields, columns, args)
+	}
+	return p.appendPagingQuery(db, fields).Select(columns, args...)
+}
+
+// buildKeysSQL builds columns of paging keys aliased as key0, key1, ... with args of placeholders in them
+func (p *Paginator) buildKeysSQL() (string, []interface{}) {
+	columns := make([]string, len(p.rules))
+	var args []interface{}
+	for i, rule := range p.rules {
+		// derived table may not have duplicate column names
+		columns[i] = fmt.Sprintf("%s AS key%d", rule.orderSQLRepr(), i)
+		args = append(args, rule.orderSQLArgs()...)
+	}
+	return strings.Join(columns, ", "), args
+}
+
+// buildOrderSQL builds ORDER BY clause of paging keys with args of placeholders in it
+func (p *Paginator) buildOrderSQL() (string, []interface{}) {
 	orders := make([]string, len(p.rules))
+	var args []interface{}
 	for i, rule := range p.rules {
 		order := rule.Order
 		if p.isBackward() {
 			order = order.flip()
 		}
-		orders[i] = fmt.Sprintf("%s %s", rule.SQLRepr, order)
+		orders[i] = fmt.Sprintf("%s %s", rule.orderSQLRepr(), order)
+		args = append(args, rule.orderSQLArgs()...)
 	}
-	return strings.Join(orders, ", ")
+	return strings.Join(orders, ", "), args
 }
 
 func (p *Paginator) buildCursorSQLQuery() string {
@@ -218,26 +551,58 @@ func (p *Paginator) buildCursorSQLQuery() string {
 			(p.isBackward() && rule.Order == DESC) {
 			operator = ">"
 		}
-		queries[i] = fmt.Sprintf("%s%s %s ?", query, rule.SQLRepr, operator)
-		query = fmt.Sprintf("%s%s = ? AND ", query, rule.SQLRepr)
+		queries[i] = fmt.Sprintf("%s%s %s ?", query, rule.orderSQLRepr(), operator)
+		query = fmt.Sprintf("%s%s = ? AND ", query, rule.orderSQLRepr())
 	}
 	// for exmaple:
 	// a > 1 OR a = 1 AND b > 2 OR a = 1 AND b = 2 AND c > 3
 	return strings.Join(queries, " OR ")
 }
 
+func (p *Paginator) buildUntilSQLQuery() string {
+	queries := make([]string, len(p.rules)+1)
+	query := ""
+	for i, rule := range p.rules {
+		// compare in paging order regardless of direction
+		operator := "<"
+		if rule.Order == DESC {
+			operator = ">"
+		}
+		queries[i] = fmt.Sprintf("%s%s %s ?", query, rule.orderSQLRepr(), operator)
+		query = fmt.Sprintf("%s%s = ? AND ", query, rule.orderSQLRepr())
+	}
+	// for example:
+	// a < 1 OR a = 1 AND b < 2 OR a = 1 AND b = 2
+	queries[len(p.rules)] = strings.TrimSuffix(query, " AND ")
+	return strings.Join(queries, " OR ")
+}
+
+func (p *Paginator) buildUntilSQLQueryArgs(fields []interface{}) []interface{} {
+	return append(p.buildCursorSQLQueryArgs(fields), p.buildKeyArgs(fields)...)
+}
+
 func (p *Paginator) buildCursorSQLQueryArgs(fields []interface{}) (args []interface{}) {
 	for i := 1; i <= len(fields); i++ {
-		args = append(args, fields[:i]...)
+		args = append(args, p.buildKeyArgs(fields[:i])...)
+	}
+	return
+}
+
+// buildKeyArgs returns args of comparisons between leading keys and fields, args of placeholders in
+// expression of key come before its field
+func (p *Paginator) buildKeyArgs(fields []interface{}) (args []interface{}) {
+	for i, field := range fields {
+		args = append(args, p.rules[i].orderSQLArgs()...)
+		args = append(args, field)
 	}
 	return
 }
 
 func (p *Paginator) encodeCursor(elems reflect.Value, hasMore bool) (result Cursor, err error) {
-	encoder := cursor.NewEncoder(p.getKeys()...)
+	codec := p.newCursorCodec(elems)
 	// encode after cursor
 	if p.isBackward() || hasMore {
-		c, err := encoder.Encode(elems.Index(elems.Len() - 1))
+		c, err := codec.encode(p.getCursorValues(elems.Index(elems.Len() - 1)))
 		if err != nil {
 			return Cursor{}, err
 		}
@@ -245,7 +610,7 @@ func (p *Paginator) encodeCursor(elems reflect.Value, hasMore bool) (result Curs
 	}
 	// encode before cursor
 	if p.isForward() || (hasMore && p.isBackward()) {
-		c, err := encoder.Encode(elems.Index(0))
+		c, err := codec.encode(p.getCursorValues(elems.Index(0)))
 		if err != nil {
 			return Cursor{}, err
 		}
@@ -254,12 +619,63 @@ func (p *Paginator) encodeCursor(elems reflect.Value, hasMore bool) (result Curs
 	return
 }
 
+func (p *Paginator) newCursorCodec(dest interface{}) *cursorCodec {
+	if p.compiled != nil && util.ReflectType(dest) == p.compiled.model {
+		return p.compiled.newCursorCodec(p)
+	}
+	types := make([]reflect.Type, len(p.rules))
+	for i, rule := range p.rules {
+		types[i] = rule.cursorType(dest)
+	}
+	if p.snapshot != nil {
+		types = append(types, p.getSnapshotBoundType(dest))
+	}
+	if p.hasShuffleRule() {
+		types = append(types, reflect.TypeOf(""))
+	}
+	return newCursorCodec(types, p.strictCursor)
+}
+
 /* rules */
 
-func (p *Paginator) getKeys() []string {
-	keys := make([]string, len(p.rules))
+func (p *Paginator) hasAggregateRule() bool {
+	for _, rule := range p.rules {
+		if rule.Aggregate {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Paginator) getCursorValues(elem reflect.Value) []interface{} {
+	values := make([]interface{}, len(p.rules))
 	for i, rule := range p.rules {
-		keys[i] = rule.Key
+		value := util.ReflectValueByPath(elem, rule.Key)
+		// cursor stores rank or hash instead of value for ranked or shuffle rules
+		if len(rule.Values) > 0 {
+			values[i] = rule.rank(value)
+		} else if rule.Shuffle {
+			values[i] = rule.shuffleHash(value)
+		} else {
+			values[i] = value.Interface()
+		}
+	}
+	if p.snapshot != nil {
+		values = append(values, p.bound)
+	}
+	if p.hasShuffleRule() {
+		values = append(values, p.seed)
+	}
+	return values
+}
+
+/* snapshot */
+
+func (p *Paginator) getSnapshotBoundType(dest interface{}) reflect.Type {
+	// bound is always a pointer, so that empty table can be represented by nil
+	f, _ := util.ReflectFieldByPath(dest, p.snapshot.Key)
+	if f.Type.Kind() == reflect.Ptr {
+		return f.Type
 	}
-	return keys
+	return reflect.PtrTo(f.Type)
 }
@@ -0,0 +1,103 @@
+package paginator
+
+import "errors"
+
+type orderStat struct {
+	OrderID   int
+	ItemCount int
+}
+
+func (s *paginatorSuite) TestPaginateAggregate() {
+	for i, n := range []int{1, 3, 2, 3} {
+		order := s.givenOrders([]TestOrder{{ID: i + 1}})[0]
+		s.givenItems(order, n)
+	}
+
+	stmt := s.db.
+		Table("items").
+		Select("items.order_id, COUNT(items.id) AS item_count").
+		Group("items.order_id")
+
+	cfg := Config{
+		Rules: []Rule{
+			{Key: "ItemCount", SQLRepr: "COUNT(items.id)", Aggregate: true},
+			{Key: "OrderID", SQLRepr: "items.order_id"},
+		},
+		Limit: 2,
+	}
+
+	var p1 []orderStat
+	_, c, err := New(&cfg).Paginate(stmt, &p1)
+	s.Nil(err)
+	s.Equal([]orderStat{{4, 3}, {2, 3}}, p1)
+	s.assertForwardOnly(c)
+
+	var p2 []orderStat
+	_, c, err = New(
+		&cfg,
+		WithAfter(*c.After),
+	).Paginate(stmt, &p2)
+	s.Nil(err)
+	s.Equal([]orderStat{{3, 2}, {1, 1}}, p2)
+	s.assertBackwardOnly(c)
+
+	var p3 []orderStat
+	_, c, err = New(
+		&cfg,
+		WithBefore(*c.Before),
+	).Paginate(stmt, &p3)
+	s.Nil(err)
+	s.Equal([]orderStat{{4, 3}, {2, 3}}, p3)
+	s.assertForwardOnly(c)
+}
+
+func (s *paginatorSuite) TestPaginateAggregateSnapshotUntil() {
+	for i, n := range []int{1, 3, 2, 3} {
+		order := s.givenOrders([]TestOrder{{ID: i + 1}})[0]
+		s.givenItems(order, n)
+	}
+
+	stmt := s.db.
+		Table("items").
+		Select("items.order_id, COUNT(items.id) AS item_count").
+		Group("items.order_id")
+
+	cfg := Config{
+		Rules: []Rule{
+			{Key: "ItemCount", SQLRepr: "COUNT(items.id)", Aggregate: true},
+			{Key: "OrderID", SQLRepr: "items.order_id"},
+		},
+		Snapshot: "OrderID",
+	}
+
+	var p1 []orderStat
+	_, c1, err := New(&cfg, WithLimit(1)).Paginate(stmt, &p1)
+	s.Nil(err)
+	s.Equal([]orderStat{{4, 3}}, p1)
+
+	var p2 []orderStat
+	_, c2, err := New(&cfg, WithLimit(3)).Paginate(stmt, &p2)
+	s.Nil(err)
+	s.Equal([]orderStat{{4, 3}, {2, 3}, {3, 2}}, p2)
+
+	// order 5 is beyond snapshot bound, but would be ordered before order 3
+	order := s.givenOrders([]TestOrder{{ID: 5}})[0]
+	s.givenItems(order, 2)
+
+	var p3 []orderStat
+	_, _, err = New(
+		&cfg,
+		WithAfter(*c1.After),
+		WithUntil(*c2.After),
+	).Paginate(stmt, &p3)
+	s.Nil(err)
+	s.Equal([]orderStat{{2, 3}, {3, 2}}, p3)
+}
+
+func (s *paginatorSuite) TestPaginateAggregateWithoutSQLRepr() {
+	var stats []orderStat
+	_, _, err := New(
+		WithRules(Rule{Key: "ItemCount", Aggregate: true}),
+	).Paginate(s.db.Table("items"), &stats)
+	s.True(errors.Is(err, ErrInvalidAggregateRule))
+}
@@ -0,0 +1,110 @@
+package paginator
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jinzhu/gorm"
+)
+
+func (s *paginatorSuite) TestEachBatchDelete() {
+	s.givenOrders(5)
+
+	var progresses []BatchProgress
+	var orders []TestOrder
+	err := New(
+		WithLimit(2),
+		WithOrder(ASC),
+	).EachBatch(context.Background(), s.db.Where("id > ?", 1), &orders, func(tx *gorm.DB) error {
+		ids := make([]int, len(orders))
+		for i, order := range orders {
+			ids[i] = order.ID
+		}
+		return tx.Where("id IN (?)", ids).Delete(&TestOrder{}).Error
+	}, func(progress BatchProgress) {
+		progresses = append(progresses, progress)
+	})
+	s.Nil(err)
+	s.Len(progresses, 2)
+	s.Equal(1, progresses[0].Batches)
+	s.Equal(2, progresses[0].Rows)
+	s.Equal(2, progresses[1].Batches)
+	s.Equal(4, progresses[1].Rows)
+
+	var rest []TestOrder
+	s.db.Find(&rest)
+	s.assertIDs(rest, 1)
+}
+
+func (s *paginatorSuite) TestEachBatchUpdate() {
+	s.givenOrders(3)
+
+	var orders []TestOrder
+	err := New(
+		WithLimit(2),
+	).EachBatch(context.Background(), s.db, &orders, func(tx *gorm.DB) error {
+		for _, order := range orders {
+			if err := tx.Model(&order).Update("remark", "done").Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	}, nil)
+	s.Nil(err)
+
+	var done int
+	s.db.Model(&TestOrder{}).Where("remark = ?", "done").Count(&done)
+	s.Equal(3, done)
+}
+
+func (s *paginatorSuite) TestEachBatchRollback() {
+	s.givenOrders(4)
+
+	errFailed := errors.New("failed")
+	var progresses []BatchProgress
+	var orders []TestOrder
+	err := New(
+		WithLimit(2),
+		WithOrder(ASC),
+	).EachBatch(context.Background(), s.db, &orders, func(tx *gorm.DB) error {
+		for _, order := range orders {
+			if err := tx.Delete(&order).Error; err != nil {
+				return err
+			}
+		}
+		if orders[0].ID == 3 {
+			return errFailed
+		}
+		return nil
+	}, func(progress BatchProgress) {
+		progresses = append(progresses, progress)
+	})
+	s.Equal(errFailed, err)
+	s.Len(progresses, 1)
+
+	// the failed batch is rolled back, and it can be resumed from progress
+	var rest []TestOrder
+	s.db.Order("id").Find(&rest)
+	s.assertIDs(rest, 3, 4)
+
+	var resumed []TestOrder
+	_, _, _ = New(
+		WithOrder(ASC),
+		WithAfter(progresses[0].After),
+	).Paginate(s.db, &resumed)
+	s.assertIDs(resumed, 3, 4)
+}
+
+func (s *paginatorSuite) TestEachBatchCanceled() {
+	s.givenOrders(1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var orders []TestOrder
+	err := New().EachBatch(ctx, s.db, &orders, func(tx *gorm.DB) error {
+		s.Fail("batch should not run")
+		return nil
+	}, nil)
+	s.Equal(context.Canceled, err)
+}
@@ -0,0 +1,225 @@
+package paginator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/require"
+)
+
+/* cache */
+
+func (s *paginatorSuite) TestPaginateCache() {
+	s.givenOrders(3)
+
+	cache := NewLRUCache(10)
+	cfg := Config{
+		Limit: 2,
+		Cache: cache,
+	}
+
+	var p1 []TestOrder
+	_, c1, err := New(&cfg).Paginate(s.db, &p1)
+	s.Nil(err)
+	s.assertIDRange(p1, 3, 2)
+	s.Equal(1, cache.Len())
+
+	s.givenOrders(1)
+
+	// served from cache
+	var p2 []TestOrder
+	_, c2, err := New(&cfg).Paginate(s.db, &p2)
+	s.Nil(err)
+	s.assertIDRange(p2, 3, 2)
+	s.Equal(c1, c2)
+
+	// cached rows are not affected by callers
+	p2[0].ID = 0
+	var p3 []TestOrder
+	_, _, _ = New(&cfg).Paginate(s.db, &p3)
+	s.assertIDRange(p3, 3, 2)
+
+	// another cursor
+	var p4 []TestOrder
+	_, _, _ = New(&cfg, WithAfter(*c1.After)).Paginate(s.db, &p4)
+	s.assertIDs(p4, 1)
+	s.Equal(2, cache.Len())
+}
+
+func (s *paginatorSuite) TestPaginateCachePointers() {
+	s.givenOrders(2)
+
+	cfg := Config{
+		Cache: NewLRUCache(10),
+	}
+
+	var p1 []*TestOrder
+	_, _, err := New(&cfg).Paginate(s.db, &p1)
+	s.Nil(err)
+	s.Len(p1, 2)
+
+	// neither rows cached by nor served to callers are shared with them
+	for i := 0; i < 2; i++ {
+		p1[0].ID = 0
+		p1 = nil
+		_, _, err = New(&cfg).Paginate(s.db, &p1)
+		s.Nil(err)
+		s.Require().Len(p1, 2)
+		s.Equal(2, p1[0].ID)
+	}
+}
+
+func (s *paginatorSuite) TestPaginateCacheTTL() {
+	s.givenOrders(1)
+
+	cache := NewLRUCache(10)
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+	cfg := Config{
+		Cache:    cache,
+		CacheTTL: time.Minute,
+	}
+
+	var p1 []TestOrder
+	_, _, _ = New(&cfg).Paginate(s.db, &p1)
+	s.assertIDs(p1, 1)
+
+	s.givenOrders(1)
+
+	var p2 []TestOrder
+	_, _, _ = New(&cfg).Paginate(s.db, &p2)
+	s.assertIDs(p2, 1)
+
+	now = now.Add(2 * time.Minute)
+
+	var p3 []TestOrder
+	_, _, _ = New(&cfg).Paginate(s.db, &p3)
+	s.assertIDRange(p3, 2, 1)
+}
+
+func (s *paginatorSuite) TestPaginateCacheByStatement() {
+	s.givenOrders(3)
+
+	cfg := Config{
+		Cache: NewLRUCache(10),
+	}
+
+	var p1 []TestOrder
+	_, _, _ = New(&cfg).Paginate(s.db.Where("id < ?", 3), &p1)
+	s.assertIDRange(p1, 2, 1)
+
+	var p2 []TestOrder
+	_, _, _ = New(&cfg).Paginate(s.db.Where("id < ?", 2), &p2)
+	s.assertIDs(p2, 1)
+
+	var p3 []TestOrder
+	_, _, _ = New(&cfg).Paginate(s.db, &p3)
+	s.assertIDRange(p3, 3, 1)
+}
+
+func (s *paginatorSuite) TestPaginatePrefetch() {
+	s.givenOrders(3)
+
+	cache := NewLRUCache(10)
+	cfg := Config{
+		Limit:    1,
+		Cache:    cache,
+		Prefetch: true,
+	}
+
+	var p1 []TestOrder
+	_, c, _ := New(&cfg).Paginate(s.db, &p1)
+	s.assertIDs(p1, 3)
+	s.Eventually(func() bool {
+		return cache.Len() == 2
+	}, time.Second, 10*time.Millisecond)
+
+	// the next page is served from cache
+	s.db.Delete(&TestOrder{ID: 2})
+
+	var p2 []TestOrder
+	_, _, _ = New(&cfg, WithAfter(*c.After)).Paginate(s.db, &p2)
+	s.assertIDs(p2, 2)
+}
+
+func (s *paginatorSuite) TestPaginatePrefetchWithinTransaction() {
+	s.givenOrders(3)
+
+	cache := NewLRUCache(10)
+	cfg := Config{
+		Limit:    1,
+		Cache:    cache,
+		Prefetch: true,
+	}
+
+	tx := s.db.Begin()
+	var p1 []TestOrder
+	_, _, err := New(&cfg).Paginate(tx, &p1)
+	s.Nil(err)
+	s.Nil(tx.Commit().Error)
+
+	// transaction is done once page is served, so the next page is not prefetched
+	s.Equal(1, cache.Len())
+}
+
+/* sqlite */
+
+func TestPaginateCacheByDatabase(t *testing.T) {
+	var dbs []*gorm.DB
+	for _, id := range []int{1, 2} {
+		db, err := gorm.Open("sqlite3", ":memory:")
+		require.Nil(t, err)
+		defer db.Close()
+		db.AutoMigrate(&TestOrder{})
+		require.Nil(t, db.Create(&TestOrder{ID: id}).Error)
+		dbs = append(dbs, db)
+	}
+
+	// the same statement on another database is not served from cache
+	cfg := Config{
+		Cache: NewLRUCache(10),
+	}
+	for i, db := range dbs {
+		var orders []TestOrder
+		_, _, err := New(&cfg).Paginate(db, &orders)
+		require.Nil(t, err)
+		require.Len(t, orders, 1)
+		require.Equal(t, i+1, orders[0].ID)
+	}
+}
+
+/* lru cache */
+
+func TestLRUCacheEviction(t *testing.T) {
+	cache := NewLRUCache(2)
+	cache.Set("a", 1, 0)
+	cache.Set("b", 2, 0)
+	_, _ = cache.Get("a")
+	cache.Set("c", 3, 0)
+
+	_, ok := cache.Get("b")
+	require.False(t, ok)
+	v, ok := cache.Get("a")
+	require.True(t, ok)
+	require.Equal(t, 1, v)
+	v, ok = cache.Get("c")
+	require.True(t, ok)
+	require.Equal(t, 3, v)
+	require.Equal(t, 2, cache.Len())
+}
+
+func TestLRUCacheExpiration(t *testing.T) {
+	cache := NewLRUCache(2)
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+	cache.Set("a", 1, time.Minute)
+
+	_, ok := cache.Get("a")
+	require.True(t, ok)
+
+	now = now.Add(2 * time.Minute)
+	_, ok = cache.Get("a")
+	require.False(t, ok)
+	require.Equal(t, 0, cache.Len())
+}
@@ -0,0 +1,100 @@
+package paginator
+
+import "errors"
+
+func (s *paginatorSuite) TestIterateResume() {
+	s.givenOrders(5)
+
+	store := NewMemoryCheckpointStore()
+	p := New(
+		WithLimit(2),
+		WithOrder(ASC),
+	)
+
+	var orders []TestOrder
+	it := p.Iterate(s.db, &orders, store, "job")
+	s.True(it.Next())
+	s.assertIDRange(orders, 1, 2)
+	s.Nil(it.Commit())
+	s.True(it.Next())
+	s.assertIDRange(orders, 3, 4)
+	s.Nil(it.Commit())
+
+	// restarted job
+	it = p.Iterate(s.db, &orders, store, "job")
+	s.True(it.Next())
+	s.assertIDs(orders, 5)
+	s.Nil(it.Commit())
+	s.False(it.Next())
+	s.Nil(it.Err())
+
+	// job is done
+	it = p.Iterate(s.db, &orders, store, "job")
+	s.False(it.Next())
+	s.Nil(it.Err())
+}
+
+func (s *paginatorSuite) TestIterateWithoutCommit() {
+	s.givenOrders(3)
+
+	store := NewMemoryCheckpointStore()
+	p := New(
+		WithLimit(2),
+		WithOrder(ASC),
+	)
+
+	var orders []TestOrder
+	it := p.Iterate(s.db, &orders, store, "job")
+	s.True(it.Next())
+	s.True(it.Next())
+	s.assertIDs(orders, 3)
+
+	it = p.Iterate(s.db, &orders, store, "job")
+	s.True(it.Next())
+	s.assertIDRange(orders, 1, 2)
+}
+
+func (s *paginatorSuite) TestIterateInvalidCheckpoint() {
+	s.givenOrders(3)
+
+	store := NewMemoryCheckpointStore()
+
+	var orders []TestOrder
+	it := New(
+		WithKeys("ID"),
+	).Iterate(s.db, &orders, store, "job")
+	s.True(it.Next())
+	s.Nil(it.Commit())
+
+	// rules are changed after restart
+	it = New(
+		WithKeys("CreatedAt", "ID"),
+	).Iterate(s.db, &orders, store, "job")
+	s.False(it.Next())
+	s.True(errors.Is(it.Err(), ErrInvalidCheckpoint))
+	// cause is kept for callers
+	var e *CursorError
+	s.Require().True(errors.As(it.Err(), &e))
+	s.Equal("CreatedAt", e.Key)
+	s.True(errors.Is(it.Err(), ErrInvalidCursor))
+	s.NotContains(it.Err().Error(), "Value0")
+}
+
+func (s *paginatorSuite) TestGormCheckpointStore() {
+	store := NewGormCheckpointStore(s.db)
+	s.Nil(store.AutoMigrate())
+	defer s.db.DropTable(&Checkpoint{})
+
+	_, ok, err := store.Load("job")
+	s.Nil(err)
+	s.False(ok)
+
+	s.Nil(store.Save("job", "cursor 1"))
+	s.Nil(store.Save("job", "cursor 2"))
+	s.Nil(store.Save("another job", "cursor 3"))
+
+	c, ok, err := store.Load("job")
+	s.Nil(err)
+	s.True(ok)
+	s.Equal("cursor 2", c)
+}
@@ -0,0 +1,89 @@
+package paginator
+
+import "errors"
+
+/* children */
+
+func (s *paginatorSuite) TestPaginateChildren() {
+	orders := s.givenOrders(3)
+	s.givenItems(orders[0], 5)
+	s.givenItems(orders[1], 2)
+
+	cfg := Config{
+		Keys:  []string{"ID"},
+		Limit: 2,
+		Order: ASC,
+	}
+
+	var items []TestItem
+	pages, err := New(&cfg).PaginateChildren(s.db, &items, "OrderID", 1, 2, 3)
+	s.Nil(err)
+	s.Len(pages, 3)
+	s.assertIDs(pages[1].Items, 1, 2)
+	s.assertForwardOnly(pages[1].Cursor)
+	s.assertIDs(pages[2].Items, 6, 7)
+	s.assertNoMore(pages[2].Cursor)
+	s.assertIDs(pages[3].Items)
+	s.assertNoMore(pages[3].Cursor)
+	s.assertIDs(items, 1, 2, 6, 7)
+
+	// cursor of a parent continues its children
+	var next []TestItem
+	pages, err = New(&cfg, WithAfter(*pages[1].Cursor.After)).PaginateChildren(s.db, &next, "OrderID", int64(1))
+	s.Nil(err)
+	s.assertIDs(pages[1].Items, 3, 4)
+	s.assertBothDirections(pages[1].Cursor)
+
+	var prev []TestItem
+	pages, err = New(&cfg, WithBefore(*pages[1].Cursor.Before)).PaginateChildren(s.db, &prev, "OrderID", 1)
+	s.Nil(err)
+	s.assertIDs(pages[1].Items, 1, 2)
+	s.assertForwardOnly(pages[1].Cursor)
+}
+
+func (s *paginatorSuite) TestPaginateChildrenCursorOfPaginate() {
+	orders := s.givenOrders(2)
+	s.givenItems(orders[0], 3)
+	s.givenItems(orders[1], 3)
+
+	cfg := Config{
+		Keys:  []string{"ID"},
+		Limit: 2,
+	}
+
+	// cursors are interchangeable with paginating children of a single parent
+	var p1 []TestItem
+	_, c, _ := New(&cfg).Paginate(s.db.Where("order_id = ?", 2), &p1)
+	s.assertIDs(p1, 6, 5)
+
+	var items []TestItem
+	pages, err := New(&cfg, WithAfter(*c.After)).PaginateChildren(s.db, &items, "OrderID", 2)
+	s.Nil(err)
+	s.assertIDs(pages[2].Items, 4)
+	s.assertBackwardOnly(pages[2].Cursor)
+}
+
+func (s *paginatorSuite) TestPaginateChildrenSubquery() {
+	orders := s.givenOrders(2)
+	s.givenItems(orders[0], 3)
+	s.givenItems(orders[1], 3)
+
+	var items []TestItem
+	pages, err := New(
+		WithKeys("ID"),
+		WithLimit(1),
+		WithSubquery(true),
+	).PaginateChildren(s.db.Table("items"), &items, "OrderID", 1, 2)
+	s.Nil(err)
+	s.assertIDs(pages[1].Items, 3)
+	s.assertIDs(pages[2].Items, 6)
+}
+
+func (s *paginatorSuite) TestPaginateChildrenInvalidParent() {
+	var items []TestItem
+	_, err := New(WithKeys("ID")).PaginateChildren(s.db, &items, "OrderID", "1")
+	s.True(errors.Is(err, ErrInvalidModel))
+
+	_, err = New(WithKeys("ID")).PaginateChildren(s.db, &items, "ParentID", 1)
+	s.True(errors.Is(err, ErrInvalidModel))
+}
@@ -0,0 +1,77 @@
+package paginator
+
+/* fixtures */
+
+// orderingCollation returns a collation ordering letters regardless of case
+func (s *paginatorSuite) orderingCollation() string {
+	if s.db.Dialect().GetName() == "sqlite3" {
+		return "NOCASE"
+	}
+	return `"und-x-icu"`
+}
+
+// byteOrderCollation returns collation ordering strings by bytes as Go does
+func (s *paginatorSuite) byteOrderCollation() string {
+	if s.db.Dialect().GetName() == "sqlite3" {
+		return "BINARY"
+	}
+	return `"C"`
+}
+
+func (s *paginatorSuite) itemNames(items []TestItem) (names []string) {
+	for _, item := range items {
+		names = append(names, item.Name)
+	}
+	return
+}
+
+/* collation */
+
+func (s *paginatorSuite) TestPaginateCollation() {
+	order := s.givenOrders(1)[0]
+	s.givenItems(order, []TestItem{
+		{Name: "Zebra", OrderID: order.ID},
+		{Name: "apple", OrderID: order.ID},
+		{Name: "cherry", OrderID: order.ID},
+		{Name: "Banana", OrderID: order.ID},
+	})
+
+	cfg := Config{
+		Rules: []Rule{
+			{Key: "Name", Collation: s.orderingCollation()},
+			{Key: "ID"},
+		},
+		Limit: 2,
+		Order: ASC,
+	}
+
+	var p1 []TestItem
+	_, c, err := New(&cfg).Paginate(s.db, &p1)
+	s.Nil(err)
+	s.Equal([]string{"apple", "Banana"}, s.itemNames(p1))
+
+	var p2 []TestItem
+	_, c, err = New(
+		&cfg,
+		WithAfter(*c.After),
+	).Paginate(s.db, &p2)
+	s.Nil(err)
+	s.Equal([]string{"cherry", "Zebra"}, s.itemNames(p2))
+	s.assertBackwardOnly(c)
+
+	var p3 []TestItem
+	_, _, err = New(
+		&cfg,
+		WithBefore(*c.Before),
+	).Paginate(s.db, &p3)
+	s.Nil(err)
+	s.Equal([]string{"apple", "Banana"}, s.itemNames(p3))
+}
+
+func (s *paginatorSuite) TestIndexSQLWithCollation() {
+	sql, err := New(
+		WithRules(Rule{Key: "Name", Collation: s.orderingCollation()}),
+	).IndexSQL(s.db, &TestItem{}, "idx_items_name")
+	s.Nil(err)
+	s.Contains(sql, `"name" COLLATE `+s.orderingCollation()+" DESC")
+}
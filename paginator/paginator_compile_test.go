@@ -0,0 +1,120 @@
+package paginator
+
+import (
+	"errors"
+	"reflect"
+)
+
+/* compile */
+
+func (s *paginatorSuite) TestCompile() {
+	s.givenOrders(5)
+
+	c, err := Compile(s.db, TestOrder{}, WithKeys("CreatedAt", "ID"), WithLimit(2))
+	s.Require().Nil(err)
+
+	var p1 []TestOrder
+	_, c1, err := c.New().Paginate(s.db, &p1)
+	s.Nil(err)
+	s.assertIDs(p1, 5, 4)
+
+	var p2 []TestOrder
+	_, c2, err := c.New(WithAfter(*c1.After)).Paginate(s.db, &p2)
+	s.Nil(err)
+	s.assertIDs(p2, 3, 2)
+
+	var p3 []TestOrder
+	_, _, err = c.New(WithBefore(*c2.Before)).Paginate(s.db, &p3)
+	s.Nil(err)
+	s.assertIDs(p3, 5, 4)
+
+	// cursors are interchangeable with paginator created as usual
+	var p4 []TestOrder
+	_, _, err = New(WithKeys("CreatedAt", "ID"), WithLimit(2), WithAfter(*c1.After)).Paginate(s.db, &p4)
+	s.Nil(err)
+	s.assertIDs(p4, 3, 2)
+}
+
+func (s *paginatorSuite) TestCompileWithOptions() {
+	s.givenOrders(3)
+
+	c, err := Compile(s.db, &[]TestOrder{}, WithKeys("ID"), WithLimit(2))
+	s.Require().Nil(err)
+
+	var p1 []TestOrder
+	_, _, err = c.New(WithOrder(ASC)).Paginate(s.db, &p1)
+	s.Nil(err)
+	s.assertIDs(p1, 1, 2)
+
+	// rules given on request are set up as usual
+	var p2 []TestOrder
+	_, _, err = c.New(WithKeys("CreatedAt"), WithLimit(1)).Paginate(s.db, &p2)
+	s.Nil(err)
+	s.assertIDs(p2, 3)
+
+	// requests do not affect each other
+	var p3 []TestOrder
+	_, _, err = c.New().Paginate(s.db, &p3)
+	s.Nil(err)
+	s.assertIDs(p3, 3, 2)
+}
+
+func (s *paginatorSuite) TestCompileWithSubquery() {
+	s.givenOrders(5)
+
+	c, err := Compile(s.db, TestOrder{}, WithKeys("ID"), WithLimit(2))
+	s.Require().Nil(err)
+
+	// columns resolved by compile are resolved again for subquery
+	stmt := s.db.Raw(
+		"SELECT * FROM orders WHERE id <= ? UNION SELECT * FROM orders WHERE id > ?",
+		2,
+		3,
+	)
+	var p1 []TestOrder
+	_, c1, err := c.New(WithSubquery(true)).Paginate(stmt, &p1)
+	s.Nil(err)
+	s.assertIDs(p1, 5, 4)
+
+	var p2 []TestOrder
+	_, _, err = c.New(WithSubquery(true), WithAfter(*c1.After)).Paginate(stmt, &p2)
+	s.Nil(err)
+	s.assertIDs(p2, 2, 1)
+
+	// compiled configuration is left untouched
+	var p3 []TestOrder
+	_, _, err = c.New().Paginate(s.db, &p3)
+	s.Nil(err)
+	s.assertIDs(p3, 5, 4)
+}
+
+func (s *paginatorSuite) TestCompileErrors() {
+	_, err := Compile(s.db, TestOrder{}, WithKeys("Unknown"))
+	var e *KeyError
+	s.Require().True(errors.As(err, &e))
+	s.Equal("Unknown", e.Key)
+	s.True(errors.Is(err, ErrInvalidModel))
+
+	_, err = Compile(s.db, TestOrder{}, WithKeys("ID"), WithLimit(-1))
+	s.Equal(ErrInvalidLimit, err)
+
+	_, err = Compile(s.db, 1, WithKeys("ID"))
+	s.Equal(ErrInvalidModel, err)
+
+	type tagged struct {
+		ID   int
+		Tags []string
+	}
+	_, err = Compile(s.db, tagged{}, WithKeys("Tags"))
+	s.Require().True(errors.As(err, &e))
+	s.Equal("Tags", e.Key)
+	s.Equal(reflect.TypeOf([]string{}), e.Type)
+	s.True(errors.Is(err, ErrUnorderableKey))
+
+	// dest should be of the compiled type
+	c, err := Compile(s.db, TestOrder{}, WithKeys("ID"))
+	s.Require().Nil(err)
+	var items []TestItem
+	_, _, err = c.New().Paginate(s.db, &items)
+	s.Equal(ErrInvalidModel, err)
+}
@@ -0,0 +1,102 @@
+package paginator
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/sqlite"
+	"github.com/stretchr/testify/suite"
+)
+
+/* postgres */
+
+func (s *paginatorSuite) TestExplainIndexBacked() {
+	s.givenOrders(3)
+
+	tx := s.db.Begin()
+	defer tx.Rollback()
+	// tiny table is always scanned sequentially unless planner is told otherwise
+	tx.Exec("SET LOCAL enable_seqscan = off")
+
+	var orders []TestOrder
+	plan, err := New(
+		WithKeys("ID"),
+	).Explain(tx, &orders)
+	s.Nil(err)
+	s.True(plan.IndexBacked(), plan.Details)
+}
+
+func (s *paginatorSuite) TestExplainSort() {
+	s.givenOrders(3)
+
+	var orders []TestOrder
+	plan, err := New(
+		WithKeys("Remark"),
+	).Explain(s.db, &orders)
+	s.Nil(err)
+	s.True(plan.Sort, plan.Details)
+	s.False(plan.IndexBacked())
+}
+
+func (s *paginatorSuite) TestExplainInvalidCursor() {
+	var orders []TestOrder
+	_, err := New(
+		WithAfter("invalid cursor"),
+	).Explain(s.db, &orders)
+	s.True(errors.Is(err, ErrInvalidCursor))
+}
+
+/* sqlite */
+
+func TestExplainSQLite(t *testing.T) {
+	suite.Run(t, &explainSQLiteSuite{})
+}
+
+type explainSQLiteSuite struct {
+	suite.Suite
+	db *gorm.DB
+}
+
+func (s *explainSQLiteSuite) SetupTest() {
+	db, err := gorm.Open("sqlite3", ":memory:")
+	if err != nil {
+		s.FailNow(err.Error())
+	}
+	s.db = db
+	s.db.AutoMigrate(&TestOrder{})
+}
+
+func (s *explainSQLiteSuite) TearDownTest() {
+	s.db.Close()
+}
+
+func (s *explainSQLiteSuite) TestPrimaryKey() {
+	var orders []TestOrder
+	plan, err := New(
+		WithKeys("ID"),
+	).Explain(s.db, &orders)
+	s.Nil(err)
+	s.True(plan.IndexBacked(), plan.Details)
+}
+
+func (s *explainSQLiteSuite) TestWithoutIndex() {
+	var orders []TestOrder
+	plan, err := New(
+		WithKeys("CreatedAt", "ID"),
+	).Explain(s.db, &orders)
+	s.Nil(err)
+	s.True(plan.Sort, plan.Details)
+	s.True(plan.FullScan, plan.Details)
+	s.False(plan.IndexBacked())
+}
+
+func (s *explainSQLiteSuite) TestWithIndex() {
+	p := New(WithKeys("CreatedAt", "ID"))
+	s.Nil(p.AutoMigrateIndex(s.db, &TestOrder{}, ""))
+
+	var orders []TestOrder
+	plan, err := p.Explain(s.db, &orders)
+	s.Nil(err)
+	s.True(plan.IndexBacked(), plan.Details)
+}
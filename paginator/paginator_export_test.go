@@ -0,0 +1,110 @@
+package paginator
+
+import (
+	"bytes"
+	"errors"
+	"time"
+)
+
+/* fixtures */
+
+func (s *paginatorSuite) givenExportOrders() {
+	remark := "gift"
+	at := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	s.givenOrders([]TestOrder{
+		{ID: 1, CreatedAt: at},
+		{ID: 2, CreatedAt: at.Add(time.Hour), Remark: &remark},
+		{ID: 3, CreatedAt: at.Add(2 * time.Hour)},
+	})
+}
+
+/* export */
+
+func (s *paginatorSuite) TestExportJSONL() {
+	s.givenExportOrders()
+
+	var buf bytes.Buffer
+	var orders []TestOrder
+	err := New(
+		WithLimit(2),
+	).Export(s.db, &orders, &buf, JSONL, nil)
+	s.Nil(err)
+	s.Equal(
+		`{"id":3,"remark":null,"created_at":"2020-01-01T02:00:00Z"}`+"\n"+
+			`{"id":2,"remark":"gift","created_at":"2020-01-01T01:00:00Z"}`+"\n"+
+			`{"id":1,"remark":null,"created_at":"2020-01-01T00:00:00Z"}`+"\n",
+		buf.String(),
+	)
+}
+
+func (s *paginatorSuite) TestExportCSV() {
+	s.givenExportOrders()
+
+	var buf bytes.Buffer
+	var orders []TestOrder
+	err := New(
+		WithLimit(2),
+		WithOrder(ASC),
+	).Export(s.db, &orders, &buf, CSV, nil)
+	s.Nil(err)
+	s.Equal(
+		"id,remark,created_at\n"+
+			"1,,2020-01-01T00:00:00Z\n"+
+			"2,gift,2020-01-01T01:00:00Z\n"+
+			"3,,2020-01-01T02:00:00Z\n",
+		buf.String(),
+	)
+}
+
+func (s *paginatorSuite) TestExportEmptyCSV() {
+	var buf bytes.Buffer
+	var orders []TestOrder
+	err := New().Export(s.db, &orders, &buf, CSV, nil)
+	s.Nil(err)
+	s.Equal("id,remark,created_at\n", buf.String())
+}
+
+func (s *paginatorSuite) TestExportResume() {
+	s.givenExportOrders()
+
+	var full bytes.Buffer
+	var orders []TestOrder
+	_ = New(WithLimit(1)).Export(s.db, &orders, &full, CSV, nil)
+
+	// interrupted after the first page
+	errInterrupted := errors.New("interrupted")
+	var checkpoint string
+	var buf bytes.Buffer
+	err := New(
+		WithLimit(1),
+	).Export(s.db, &orders, &buf, CSV, func(after string) error {
+		checkpoint = after
+		return errInterrupted
+	})
+	s.Equal(errInterrupted, err)
+
+	err = New(
+		WithLimit(1),
+		WithAfter(checkpoint),
+	).Export(s.db, &orders, &buf, CSV, func(after string) error {
+		checkpoint = after
+		return nil
+	})
+	s.Nil(err)
+	s.Equal(full.String(), buf.String())
+
+	// nothing left after the last checkpoint
+	var rest bytes.Buffer
+	_ = New(
+		WithLimit(1),
+		WithAfter(checkpoint),
+	).Export(s.db, &orders, &rest, CSV, nil)
+	s.Equal("", rest.String())
+}
+
+func (s *paginatorSuite) TestExportUnsupportedFormat() {
+	var buf bytes.Buffer
+	var orders []TestOrder
+	err := New().Export(s.db, &orders, &buf, Format("xml"), nil)
+	s.Equal(ErrUnsupportedFormat, err)
+}
@@ -0,0 +1,147 @@
+package paginator
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+func (s *paginatorSuite) TestFollow() {
+	s.givenOrders([]TestOrder{{ID: 1}, {ID: 2}, {ID: 4}})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	p := New(
+		WithLimit(2),
+		WithPollInterval(10*time.Millisecond),
+		WithLagWindow(2),
+	)
+
+	var ids []int
+	var orders []TestOrder
+	err := p.Follow(ctx, s.db, &orders, func(c Cursor) error {
+		for _, order := range orders {
+			ids = append(ids, order.ID)
+			// order 3 commits after order 4 is delivered
+			if order.ID == 4 {
+				s.givenOrders([]TestOrder{{ID: 3}})
+			}
+		}
+		if len(ids) == 4 {
+			cancel()
+		}
+		return nil
+	})
+	s.Equal(context.Canceled, err)
+	s.Equal([]int{1, 2, 4, 3}, ids)
+}
+
+func (s *paginatorSuite) TestFollowFromCursor() {
+	s.givenOrders(3)
+
+	var p1 []TestOrder
+	_, c, _ := New(
+		WithLimit(1),
+		WithOrder(ASC),
+	).Paginate(s.db, &p1)
+
+	errStop := errors.New("stop")
+	var orders []TestOrder
+	err := New(
+		WithAfter(*c.After),
+	).Follow(context.Background(), s.db, &orders, func(c Cursor) error {
+		return errStop
+	})
+	s.Equal(errStop, err)
+	s.assertIDRange(orders, 2, 3)
+}
+
+func (s *paginatorSuite) TestFollowWithoutLagWindow() {
+	s.givenOrders([]TestOrder{{ID: 1}, {ID: 3}})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var ids []int
+	var orders []TestOrder
+	err := New(
+		WithPollInterval(10*time.Millisecond),
+	).Follow(ctx, s.db, &orders, func(c Cursor) error {
+		for _, order := range orders {
+			ids = append(ids, order.ID)
+			// order 2 commits late along with order 4, so both are ready by the next poll
+			if order.ID == 3 {
+				s.givenOrders([]TestOrder{{ID: 2}, {ID: 4}})
+			}
+			if order.ID == 4 {
+				cancel()
+			}
+		}
+		return nil
+	})
+	s.Equal(context.Canceled, err)
+	// late order falls behind cursor
+	s.Equal([]int{1, 3, 4}, ids)
+}
+
+func (s *paginatorSuite) TestFollowCancelDuringBacklog() {
+	s.givenOrders(10)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var ids []int
+	var orders []TestOrder
+	err := New(
+		WithLimit(2),
+		WithPollInterval(time.Hour),
+	).Follow(ctx, s.db, &orders, func(c Cursor) error {
+		for _, order := range orders {
+			ids = append(ids, order.ID)
+		}
+		// backlog is not drained once canceled
+		cancel()
+		return nil
+	})
+	s.Equal(context.Canceled, err)
+	s.Equal([]int{1, 2}, ids)
+}
+
+func (s *paginatorSuite) TestFollowSnapshot() {
+	s.givenOrders(3)
+
+	cfg := Config{
+		Keys:     []string{"ID"},
+		Limit:    1,
+		Order:    ASC,
+		Snapshot: "ID",
+	}
+	var p1 []TestOrder
+	_, c, _ := New(&cfg).Paginate(s.db, &p1)
+	s.givenOrders([]TestOrder{{ID: 4}})
+
+	// rows beyond snapshot bound carried by cursor are not followed
+	errStop := errors.New("stop")
+	var orders []TestOrder
+	err := New(
+		&cfg,
+		WithLimit(10),
+		WithAfter(*c.After),
+	).Follow(context.Background(), s.db, &orders, func(c Cursor) error {
+		return errStop
+	})
+	s.Equal(errStop, err)
+	s.assertIDRange(orders, 2, 3)
+}
+
+func (s *paginatorSuite) TestFollowInvalidPollInterval() {
+	p := New()
+	p.SetPollInterval(0)
+
+	var orders []TestOrder
+	err := p.Follow(context.Background(), s.db, &orders, func(c Cursor) error {
+		return nil
+	})
+	s.Equal(ErrInvalidPollInterval, err)
+}
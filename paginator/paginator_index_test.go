@@ -0,0 +1,87 @@
+package paginator
+
+import "errors"
+
+func (s *paginatorSuite) TestIndexSQL() {
+	sql, err := New(
+		WithKeys("CreatedAt", "ID"),
+	).IndexSQL(s.db, &TestOrder{}, "")
+	s.Nil(err)
+	s.Equal(
+		`CREATE INDEX "idx_orders_created_at_id" ON "orders" ("created_at" DESC NULLS FIRST, "id" DESC NULLS FIRST)`,
+		sql,
+	)
+}
+
+func (s *paginatorSuite) TestIndexSQLWithRules() {
+	sql, err := New(&Config{
+		Rules: []Rule{
+			{
+				Key:   "CreatedAt",
+				Order: ASC,
+			},
+			{
+				Key: "ID",
+			},
+		},
+		Order: DESC,
+	}).IndexSQL(s.db, &TestOrder{}, "orders_paging")
+	s.Nil(err)
+	s.Equal(
+		`CREATE INDEX "orders_paging" ON "orders" ("created_at" ASC NULLS LAST, "id" DESC NULLS FIRST)`,
+		sql,
+	)
+}
+
+func (s *paginatorSuite) TestIndexSQLQuotedIdentifiers() {
+	sql, err := New(&Config{
+		Rules: []Rule{
+			{
+				Key:     "CreatedAt",
+				SQLRepr: "orders.CreatedAt",
+			},
+			{
+				Key:     "ID",
+				SQLRepr: "orders.order",
+			},
+		},
+	}).IndexSQL(s.db, &TestOrder{}, "order")
+	s.Nil(err)
+	s.Equal(
+		`CREATE INDEX "order" ON "orders" ("CreatedAt" DESC NULLS FIRST, "order" DESC NULLS FIRST)`,
+		sql,
+	)
+}
+
+func (s *paginatorSuite) TestIndexSQLJoinedTableKey() {
+	_, err := New(&Config{
+		Rules: []Rule{
+			{
+				Key:     "ID",
+				SQLRepr: "items.id",
+			},
+		},
+	}).IndexSQL(s.db, &TestOrder{}, "")
+	s.Equal(ErrInvalidIndexRule, err)
+}
+
+func (s *paginatorSuite) TestIndexSQLInvalidModel() {
+	var unknown struct {
+		UnknownKey string
+	}
+	_, err := New(
+		WithKeys("ID"),
+	).IndexSQL(s.db, &unknown, "")
+	s.True(errors.Is(err, ErrInvalidModel))
+}
+
+func (s *paginatorSuite) TestAutoMigrateIndex() {
+	name := "idx_orders_created_at_id"
+	defer s.db.Model(&TestOrder{}).RemoveIndex(name)
+
+	err := New(
+		WithKeys("CreatedAt", "ID"),
+	).AutoMigrateIndex(s.db, &TestOrder{}, "")
+	s.Nil(err)
+	s.True(s.db.Dialect().HasIndex("orders", name))
+}
@@ -0,0 +1,85 @@
+package paginator
+
+func (s *paginatorSuite) TestPaginateWithInfo() {
+	s.givenOrders(10)
+
+	cfg := Config{
+		Limit:     2,
+		LookAhead: 3,
+	}
+
+	var p1 []TestOrder
+	_, c, info, err := New(&cfg).PaginateWithInfo(s.db, &p1)
+	s.Nil(err)
+	s.assertIDRange(p1, 10, 9)
+	s.Equal(PageInfo{MorePages: 3, MorePagesCapped: true}, info)
+
+	var p2 []TestOrder
+	_, c, info, _ = New(&cfg, WithAfter(*c.After)).PaginateWithInfo(s.db, &p2)
+	s.assertIDRange(p2, 8, 7)
+	s.Equal(PageInfo{MorePages: 3}, info)
+
+	var p3 []TestOrder
+	_, c, info, _ = New(&cfg, WithAfter(*c.After)).PaginateWithInfo(s.db, &p3)
+	s.assertIDRange(p3, 6, 5)
+	s.Equal(PageInfo{MorePages: 2}, info)
+
+	// look ahead backward
+	var p4 []TestOrder
+	_, _, info, _ = New(&cfg, WithBefore(*c.Before)).PaginateWithInfo(s.db, &p4)
+	s.assertIDRange(p4, 8, 7)
+	s.Equal(PageInfo{MorePages: 1}, info)
+}
+
+func (s *paginatorSuite) TestPaginateWithInfoLastPage() {
+	s.givenOrders(3)
+
+	var orders []TestOrder
+	_, _, info, err := New(
+		WithLimit(3),
+		WithLookAhead(3),
+	).PaginateWithInfo(s.db, &orders)
+	s.Nil(err)
+	s.assertIDRange(orders, 3, 1)
+	s.Equal(PageInfo{}, info)
+}
+
+func (s *paginatorSuite) TestPaginateWithInfoWithoutLookAhead() {
+	s.givenOrders(3)
+
+	var orders []TestOrder
+	_, _, info, _ := New(
+		WithLimit(2),
+	).PaginateWithInfo(s.db, &orders)
+	s.Equal(PageInfo{}, info)
+}
+
+func (s *paginatorSuite) TestPaginateWithInfoSubquery() {
+	s.givenOrders(5)
+
+	var orders []TestOrder
+	_, _, info, err := New(
+		WithLimit(2),
+		WithLookAhead(3),
+		WithSubquery(true),
+	).PaginateWithInfo(s.db.Model(&TestOrder{}), &orders)
+	s.Nil(err)
+	s.assertIDRange(orders, 5, 4)
+	s.Equal(PageInfo{MorePages: 2}, info)
+}
+
+func (s *paginatorSuite) TestEstimateTotal() {
+	s.givenOrders(10)
+	s.db.Exec("ANALYZE orders")
+
+	var orders []TestOrder
+	total, err := EstimateTotal(s.db, &orders)
+	s.Nil(err)
+	s.Equal(int64(10), total)
+}
+
+func (s *explainSQLiteSuite) TestEstimateTotalUnsupported() {
+	var orders []TestOrder
+	_, err := EstimateTotal(s.db, &orders)
+	s.Equal(ErrUnsupportedDialect, err)
+}
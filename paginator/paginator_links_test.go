@@ -0,0 +1,138 @@
+package paginator
+
+func (s *paginatorSuite) TestPageLinks() {
+	s.givenOrders(9)
+
+	cfg := Config{
+		Limit: 2,
+	}
+
+	var orders []TestOrder
+	links, err := New(&cfg).PageLinks(s.db, &orders, 3)
+	s.Nil(err)
+	s.Len(links, 3)
+
+	// jump to the 3rd page after current page
+	var p4 []TestOrder
+	_, c, _ := New(
+		&cfg,
+		WithAfter(links[2]),
+	).Paginate(s.db, &p4)
+	s.assertIDRange(p4, 3, 2)
+	s.assertBothDirections(c)
+
+	// page links from the jumped position
+	links, _ = New(
+		&cfg,
+		WithAfter(links[2]),
+	).PageLinks(s.db, &orders, 3)
+	s.Len(links, 1)
+
+	var p5 []TestOrder
+	_, c, _ = New(
+		&cfg,
+		WithAfter(links[0]),
+	).Paginate(s.db, &p5)
+	s.assertIDs(p5, 1)
+	s.assertBackwardOnly(c)
+}
+
+func (s *paginatorSuite) TestPageLinksExactPages() {
+	s.givenOrders(6)
+
+	var orders []TestOrder
+	links, err := New(
+		WithLimit(2),
+	).PageLinks(s.db, &orders, 5)
+	s.Nil(err)
+	s.Len(links, 2)
+}
+
+func (s *paginatorSuite) TestPageLinksBackward() {
+	s.givenOrders(9)
+
+	cfg := Config{
+		Limit: 2,
+	}
+
+	var orders []TestOrder
+	links, _ := New(&cfg).PageLinks(s.db, &orders, 4)
+
+	var p1 []TestOrder
+	_, c, _ := New(
+		&cfg,
+		WithAfter(links[3]),
+	).Paginate(s.db, &p1)
+	s.assertIDs(p1, 1)
+
+	links, err := New(
+		&cfg,
+		WithBefore(*c.Before),
+	).PageLinks(s.db, &orders, 5)
+	s.Nil(err)
+	s.Len(links, 3)
+
+	var p2 []TestOrder
+	_, c, _ = New(
+		&cfg,
+		WithBefore(links[2]),
+	).Paginate(s.db, &p2)
+	s.assertIDs(p2, 9, 8)
+	s.assertForwardOnly(c)
+}
+
+func (s *paginatorSuite) TestPageLinksWithSnapshot() {
+	s.givenOrders(4)
+
+	cfg := Config{
+		Limit:    2,
+		Snapshot: "ID",
+	}
+
+	var orders []TestOrder
+	links, err := New(&cfg).PageLinks(s.db, &orders, 3)
+	s.Nil(err)
+	s.Len(links, 1)
+
+	s.givenOrders(2)
+
+	var p2 []TestOrder
+	_, _, err = New(
+		&cfg,
+		WithAfter(links[0]),
+	).Paginate(s.db, &p2)
+	s.Nil(err)
+	s.assertIDRange(p2, 2, 1)
+}
+
+func (s *paginatorSuite) TestPageLinksByMultipleKeys() {
+	s.givenOrders(5)
+
+	cfg := Config{
+		Keys:  []string{"CreatedAt", "ID"},
+		Limit: 2,
+	}
+
+	var orders []TestOrder
+	links, err := New(&cfg).PageLinks(s.db, &orders, 2)
+	s.Nil(err)
+	s.Len(links, 2)
+
+	var p3 []TestOrder
+	_, _, err = New(
+		&cfg,
+		WithAfter(links[1]),
+	).Paginate(s.db, &p3)
+	s.Nil(err)
+	s.assertIDs(p3, 1)
+}
+
+func (s *paginatorSuite) TestPageLinksInvalidPages() {
+	s.givenOrders(3)
+
+	var orders []TestOrder
+	for _, k := range []int{0, -1} {
+		_, err := New(WithLimit(2)).PageLinks(s.db, &orders, k)
+		s.Equal(ErrInvalidPageLinks, err)
+	}
+}
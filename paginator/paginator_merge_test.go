@@ -0,0 +1,277 @@
+package paginator
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/gorm-cursor-paginator/cursor"
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/require"
+)
+
+/* merge */
+
+func (s *paginatorSuite) TestPaginateShards() {
+	s.givenOrders(10)
+
+	shards := []*gorm.DB{
+		s.db.Where("id % 2 = 0"),
+		s.db.Where("id % 2 = 1"),
+	}
+	cfg := Config{
+		Keys:  []string{"ID"},
+		Limit: 3,
+		Order: ASC,
+	}
+
+	var p1 []TestOrder
+	c, err := New(&cfg).PaginateShards(shards, &p1)
+	s.Nil(err)
+	s.assertIDs(p1, 1, 2, 3)
+	s.assertForwardOnly(c)
+
+	var p2 []TestOrder
+	c, err = New(&cfg, WithAfter(*c.After)).PaginateShards(shards, &p2)
+	s.Nil(err)
+	s.assertIDs(p2, 4, 5, 6)
+	s.assertBothDirections(c)
+
+	var p3 []TestOrder
+	c, err = New(&cfg, WithAfter(*c.After)).PaginateShards(shards, &p3)
+	s.Nil(err)
+	s.assertIDs(p3, 7, 8, 9)
+
+	var p4 []TestOrder
+	c, err = New(&cfg, WithAfter(*c.After)).PaginateShards(shards, &p4)
+	s.Nil(err)
+	s.assertIDs(p4, 10)
+	s.assertBackwardOnly(c)
+
+	var p3Again []TestOrder
+	c, err = New(&cfg, WithBefore(*c.Before)).PaginateShards(shards, &p3Again)
+	s.Nil(err)
+	s.assertIDs(p3Again, 7, 8, 9)
+	s.assertBothDirections(c)
+
+	var p2Again []TestOrder
+	_, err = New(&cfg, WithBefore(*c.Before)).PaginateShards(shards, &p2Again)
+	s.Nil(err)
+	s.assertIDs(p2Again, 4, 5, 6)
+}
+
+func (s *paginatorSuite) TestPaginateShardsUneven() {
+	s.givenOrders(10)
+
+	// the first shard runs out of rows on the first page
+	shards := []*gorm.DB{
+		s.db.Where("id <= 2"),
+		s.db.Where("id > 2"),
+	}
+	cfg := Config{
+		Keys:  []string{"CreatedAt", "ID"},
+		Limit: 4,
+	}
+
+	var p1 []TestOrder
+	c, err := New(&cfg).PaginateShards(shards, &p1)
+	s.Nil(err)
+	s.assertIDs(p1, 10, 9, 8, 7)
+
+	var p2 []TestOrder
+	c, err = New(&cfg, WithAfter(*c.After)).PaginateShards(shards, &p2)
+	s.Nil(err)
+	s.assertIDs(p2, 6, 5, 4, 3)
+
+	var p3 []TestOrder
+	c, err = New(&cfg, WithAfter(*c.After)).PaginateShards(shards, &p3)
+	s.Nil(err)
+	s.assertIDs(p3, 2, 1)
+	s.assertBackwardOnly(c)
+
+	var p2Again []TestOrder
+	c, err = New(&cfg, WithBefore(*c.Before)).PaginateShards(shards, &p2Again)
+	s.Nil(err)
+	s.assertIDs(p2Again, 6, 5, 4, 3)
+
+	var p1Again []TestOrder
+	c, err = New(&cfg, WithBefore(*c.Before)).PaginateShards(shards, &p1Again)
+	s.Nil(err)
+	s.assertIDs(p1Again, 10, 9, 8, 7)
+	s.assertForwardOnly(c)
+}
+
+func (s *paginatorSuite) TestPaginateShardsTies() {
+	s.givenOrders(3)
+
+	// shards holding the same rows tie on every key
+	shards := []*gorm.DB{s.db, s.db}
+	cfg := Config{
+		Keys:  []string{"ID"},
+		Limit: 4,
+		Order: ASC,
+	}
+
+	var p1 []TestOrder
+	c, err := New(&cfg).PaginateShards(shards, &p1)
+	s.Nil(err)
+	s.assertIDs(p1, 1, 1, 2, 2)
+
+	var p2 []TestOrder
+	c, err = New(&cfg, WithAfter(*c.After)).PaginateShards(shards, &p2)
+	s.Nil(err)
+	s.assertIDs(p2, 3, 3)
+
+	var p1Again []TestOrder
+	_, err = New(&cfg, WithBefore(*c.Before)).PaginateShards(shards, &p1Again)
+	s.Nil(err)
+	s.Equal(p1, p1Again)
+}
+
+func (s *paginatorSuite) TestPaginateShardsInvalidCursor() {
+	s.givenOrders(3)
+
+	cfg := Config{
+		Keys:  []string{"ID"},
+		Limit: 1,
+	}
+
+	var p1 []TestOrder
+	c, _ := New(&cfg).PaginateShards([]*gorm.DB{s.db, s.db}, &p1)
+
+	// cursor holds position of every shard
+	var p2 []TestOrder
+	_, err := New(&cfg, WithAfter(*c.After)).PaginateShards([]*gorm.DB{s.db, s.db, s.db}, &p2)
+	s.True(errors.Is(err, ErrInvalidCursor))
+}
+
+func (s *paginatorSuite) TestPaginateShardsStrictCursor() {
+	s.givenOrders(6)
+
+	shards := []*gorm.DB{
+		s.db.Where("id % 3 = 0"),
+		s.db.Where("id % 3 = 1"),
+		s.db.Where("id % 3 = 2"),
+	}
+	// limits are of a single shard cursor, merge cursor holding three of them is longer
+	cfg := Config{
+		Keys:         []string{"ID"},
+		Limit:        2,
+		Order:        ASC,
+		StrictCursor: &cursor.Limits{MaxLength: 8, MaxStringSize: 4, MaxDepth: 1},
+	}
+
+	var p1 []TestOrder
+	c, err := New(&cfg).PaginateShards(shards, &p1)
+	s.Nil(err)
+	s.assertIDs(p1, 1, 2)
+
+	var p2 []TestOrder
+	c, err = New(&cfg, WithAfter(*c.After)).PaginateShards(shards, &p2)
+	s.Nil(err)
+	s.assertIDs(p2, 3, 4)
+
+	var p1Again []TestOrder
+	_, err = New(&cfg, WithBefore(*c.Before)).PaginateShards(shards, &p1Again)
+	s.Nil(err)
+	s.assertIDs(p1Again, 1, 2)
+
+	// cursors of shards are still decoded strictly
+	position, _ := cursor.NewEncoder("ID", "ID").Encode(TestOrder{ID: 1})
+	b, _ := json.Marshal([]interface{}{position, nil, nil})
+	var p3 []TestOrder
+	_, err = New(
+		&cfg,
+		WithAfter(base64.StdEncoding.EncodeToString(b)),
+	).PaginateShards(shards, &p3)
+	s.True(errors.Is(err, ErrInvalidCursor))
+	s.True(errors.Is(err, cursor.ErrElementCount))
+}
+
+func (s *paginatorSuite) TestPaginateShardsStringsAndNulls() {
+	remark := func(r string) *string { return &r }
+	s.givenOrders([]TestOrder{
+		{Remark: remark("b")},
+		{},
+		{Remark: remark("B")},
+		{Remark: remark("a")},
+		{},
+		{Remark: remark("A")},
+	})
+
+	cfg := Config{
+		Rules: []Rule{
+			{Key: "Remark", Collation: s.byteOrderCollation()},
+			{Key: "ID"},
+		},
+		Limit: 10,
+		Order: ASC,
+	}
+
+	// merged page agrees with the database on order of strings and placement of NULL
+	var orders []TestOrder
+	_, _, err := New(&cfg).Paginate(s.db, &orders)
+	s.Require().Nil(err)
+
+	var merged []TestOrder
+	_, err = New(&cfg).PaginateShards([]*gorm.DB{
+		s.db.Where("id % 2 = 0"),
+		s.db.Where("id % 2 = 1"),
+	}, &merged)
+	s.Nil(err)
+	var ids []int
+	for _, order := range orders {
+		ids = append(ids, order.ID)
+	}
+	s.assertIDs(merged, ids...)
+}
+
+func (s *paginatorSuite) TestPaginateShardsInvalidMergeRule() {
+	var orders []TestOrder
+	_, err := New(
+		WithRules(Rule{Key: "Remark", Collation: s.orderingCollation()}),
+		WithLimit(1),
+	).PaginateShards([]*gorm.DB{s.db}, &orders)
+	s.Equal(ErrInvalidMergeRule, err)
+
+	// default collations of postgres and mysql are not known to order by bytes
+	if s.db.Dialect().GetName() != "sqlite3" {
+		_, err = New(
+			WithRules(Rule{Key: "Remark"}),
+			WithLimit(1),
+		).PaginateShards([]*gorm.DB{s.db}, &orders)
+		s.Equal(ErrInvalidMergeRule, err)
+	}
+}
+
+/* sqlite */
+
+func TestPaginateShardsCache(t *testing.T) {
+	var shards []*gorm.DB
+	for _, ids := range [][]int{{1, 3, 5}, {2, 4, 6}} {
+		db, err := gorm.Open("sqlite3", ":memory:")
+		require.Nil(t, err)
+		defer db.Close()
+		db.AutoMigrate(&TestOrder{})
+		for _, id := range ids {
+			require.Nil(t, db.Create(&TestOrder{ID: id}).Error)
+		}
+		shards = append(shards, db)
+	}
+
+	// shards run the same statement on different databases, so their pages are not served from cache
+	var orders []TestOrder
+	_, err := New(
+		WithKeys("ID"),
+		WithLimit(4),
+		WithOrder(ASC),
+		WithCache(NewLRUCache(10), 0),
+	).PaginateShards(shards, &orders)
+	require.Nil(t, err)
+	var ids []int
+	for _, order := range orders {
+		ids = append(ids, order.ID)
+	}
+	require.Equal(t, []int{1, 2, 3, 4}, ids)
+}
@@ -1,5 +1,13 @@
 package paginator
 
+import (
+	"errors"
+	"reflect"
+	"time"
+
+	"github.com/hashicorp/gorm-cursor-paginator/cursor"
+)
+
 func (s *paginatorSuite) TestPaginateNoRule() {
 	var orders []TestOrder
 	_, _, err := New(&Config{
@@ -34,7 +42,7 @@ func (s *paginatorSuite) TestPaginateInvalidOrderOnRules() {
 			},
 		},
 	}).Paginate(s.db, &orders)
-	s.Equal(ErrInvalidOrder, err)
+	s.True(errors.Is(err, ErrInvalidOrder))
 }
 
 func (s *paginatorSuite) TestPaginateInvalidAfterCursor() {
@@ -42,7 +50,7 @@ func (s *paginatorSuite) TestPaginateInvalidAfterCursor() {
 	_, _, err := New(
 		WithAfter("invalid cursor"),
 	).Paginate(s.db, &orders)
-	s.Equal(ErrInvalidCursor, err)
+	s.True(errors.Is(err, ErrInvalidCursor))
 }
 
 func (s *paginatorSuite) TestPaginateInvalidBeforeCursor() {
@@ -50,7 +58,7 @@ func (s *paginatorSuite) TestPaginateInvalidBeforeCursor() {
 	_, _, err := New(
 		WithBefore("invalid cursor"),
 	).Paginate(s.db, &orders)
-	s.Equal(ErrInvalidCursor, err)
+	s.True(errors.Is(err, ErrInvalidCursor))
 }
 
 func (s *paginatorSuite) TestPaginateInvalidModel() {
@@ -60,5 +68,54 @@ func (s *paginatorSuite) TestPaginateInvalidModel() {
 	_, _, err := New(
 		WithKeys("ID"),
 	).Paginate(s.db, &unknown)
-	s.Equal(ErrInvalidModel, err)
+	s.True(errors.Is(err, ErrInvalidModel))
+}
+
+func (s *paginatorSuite) TestPaginateKeyError() {
+	var orders []TestOrder
+	_, _, err := New(
+		WithRules(Rule{Key: "Remark", Values: []interface{}{1, 2}}),
+	).Paginate(s.db, &orders)
+	var e *KeyError
+	s.Require().True(errors.As(err, &e))
+	s.Equal("Remark", e.Key)
+	s.Equal(reflect.TypeOf((*string)(nil)), e.Type)
+	s.True(errors.Is(err, ErrInvalidRuleValues))
+
+	_, _, err = New(WithKeys("Unknown")).Paginate(s.db, &orders)
+	s.Require().True(errors.As(err, &e))
+	s.Equal("Unknown", e.Key)
+	s.Nil(e.Type)
+	s.True(errors.Is(err, ErrInvalidModel))
+}
+
+func (s *paginatorSuite) TestPaginateCursorError() {
+	s.givenOrders(3)
+
+	var p1 []TestOrder
+	_, c, _ := New(WithKeys("ID"), WithLimit(1)).Paginate(s.db, &p1)
+
+	// cursor issued under different keys
+	var p2 []TestOrder
+	_, _, err := New(WithKeys("CreatedAt", "ID"), WithAfter(*c.After)).Paginate(s.db, &p2)
+	var e *CursorError
+	s.Require().True(errors.As(err, &e))
+	s.Equal("CreatedAt", e.Key)
+	s.Equal(reflect.TypeOf(time.Time{}), e.Type)
+	s.Equal(0, e.Position)
+	s.True(errors.Is(err, ErrInvalidCursor))
+	s.True(errors.Is(err, cursor.ErrInvalidCursor))
+
+	// cursor with fewer values than keys
+	_, _, err = New(WithKeys("ID", "CreatedAt"), WithAfter(*c.After)).Paginate(s.db, &p2)
+	s.Require().True(errors.As(err, &e))
+	s.Equal("CreatedAt", e.Key)
+	s.Equal(1, e.Position)
+
+	// malformed cursor
+	_, _, err = New(WithKeys("ID"), WithAfter("invalid cursor")).Paginate(s.db, &p2)
+	s.Require().True(errors.As(err, &e))
+	s.Equal("", e.Key)
+	s.Equal(-1, e.Position)
+	s.NotNil(e.Cause)
 }
@@ -0,0 +1,240 @@
+package paginator
+
+import (
+	"errors"
+	"sort"
+)
+
+/* fixtures */
+
+func (s *paginatorSuite) walkRange(cfg *Config, r Range) (ids []int) {
+	opts := []Option{cfg}
+	if r.After != nil {
+		opts = append(opts, WithAfter(*r.After))
+	}
+	if r.Until != nil {
+		opts = append(opts, WithUntil(*r.Until))
+	}
+	for {
+		var orders []TestOrder
+		_, c, err := New(opts...).Paginate(s.db, &orders)
+		if err != nil {
+			s.FailNow(err.Error())
+		}
+		for _, order := range orders {
+			ids = append(ids, order.ID)
+		}
+		if c.After == nil {
+			return
+		}
+		opts = append(opts, WithAfter(*c.After))
+	}
+}
+
+func (s *paginatorSuite) assertPartitioned(cfg *Config, ranges []Range, n int) {
+	var ids []int
+	for _, r := range ranges {
+		ids = append(ids, s.walkRange(cfg, r)...)
+	}
+	sort.Ints(ids)
+	expected := make([]int, n)
+	for i := range expected {
+		expected[i] = i + 1
+	}
+	s.Equal(expected, ids)
+}
+
+/* partition */
+
+func (s *paginatorSuite) TestPartitionNumericKey() {
+	s.givenOrders(10)
+
+	cfg := Config{
+		Limit: 2,
+	}
+
+	var orders []TestOrder
+	ranges, err := New(&cfg).Partition(s.db, &orders, 3)
+	s.Nil(err)
+	s.Len(ranges, 3)
+	s.Nil(ranges[0].After)
+	s.Nil(ranges[2].Until)
+	s.Equal([]int{10, 9, 8, 7}, s.walkRange(&cfg, ranges[0]))
+	s.assertPartitioned(&cfg, ranges, 10)
+}
+
+func (s *paginatorSuite) TestPartitionLargeIntegerKey() {
+	s.givenOrders(4)
+
+	// keys beyond 2^53 are not exact in float64
+	cfg := Config{
+		Rules: []Rule{{Key: "ID", SQLRepr: "orders.id + 9007199254740992"}},
+		Order: ASC,
+	}
+
+	var orders []TestOrder
+	ranges, err := New(&cfg).Partition(s.db, &orders, 4)
+	s.Nil(err)
+	s.Len(ranges, 4)
+	for i, r := range ranges {
+		s.Equal([]int{i + 1}, s.walkRange(&cfg, r))
+	}
+}
+
+func (s *paginatorSuite) TestPartitionInvalidPartitions() {
+	var orders []TestOrder
+	for _, n := range []int{0, -1} {
+		_, err := New().Partition(s.db, &orders, n)
+		s.Equal(ErrInvalidPartitions, err)
+	}
+}
+
+func (s *paginatorSuite) TestPartitionUnsupported() {
+	var orders []TestOrder
+	_, err := New(
+		WithRules(Rule{Key: "ID", SQLRepr: "COUNT(orders.id)", Aggregate: true}),
+	).Partition(s.db, &orders, 2)
+	s.Equal(ErrUnsupportedPartition, err)
+
+	s.givenOrders(3)
+	var p1 []TestOrder
+	_, c, _ := New(WithLimit(1)).Paginate(s.db, &p1)
+	_, err = New(WithBefore(*c.After)).Partition(s.db, &orders, 2)
+	s.Equal(ErrUnsupportedPartition, err)
+}
+
+func (s *paginatorSuite) TestPartitionWithCursors() {
+	s.givenOrders(10)
+
+	for _, keys := range [][]string{{"ID"}, {"CreatedAt", "ID"}} {
+		cfg := Config{
+			Keys:  keys,
+			Limit: 2,
+		}
+		// pages are 10 9, 8 7, 6 5, 4 3
+		var cursors []string
+		var after []Option
+		for i := 0; i < 4; i++ {
+			var orders []TestOrder
+			_, c, err := New(append([]Option{&cfg}, after...)...).Paginate(s.db, &orders)
+			s.Require().Nil(err)
+			cursors = append(cursors, *c.After)
+			after = []Option{WithAfter(*c.After)}
+		}
+
+		// only rows after the first page and until the last page are partitioned
+		var orders []TestOrder
+		ranges, err := New(
+			&cfg,
+			WithAfter(cursors[0]),
+			WithUntil(cursors[3]),
+		).Partition(s.db, &orders, 3)
+		s.Nil(err)
+		s.Require().NotEmpty(ranges)
+		s.Equal(cursors[0], *ranges[0].After)
+		s.Equal(cursors[3], *ranges[len(ranges)-1].Until)
+		var walked [][]int
+		for _, r := range ranges {
+			walked = append(walked, s.walkRange(&cfg, r))
+		}
+		s.Equal([][]int{{8, 7}, {6, 5}, {4, 3}}, walked)
+	}
+}
+
+func (s *paginatorSuite) TestPartitionSnapshot() {
+	s.givenOrders(10)
+
+	cfg := Config{
+		Keys:     []string{"ID"},
+		Limit:    2,
+		Order:    ASC,
+		Snapshot: "ID",
+	}
+	var p1 []TestOrder
+	_, c, _ := New(&cfg).Paginate(s.db, &p1)
+	s.givenOrders(5)
+
+	// boundaries are interpolated within snapshot bound, i.e., between 3 and 10
+	var orders []TestOrder
+	ranges, err := New(&cfg, WithAfter(*c.After)).Partition(s.db, &orders, 2)
+	s.Nil(err)
+	s.Require().Len(ranges, 2)
+	s.Equal([]int{3, 4, 5, 6}, s.walkRange(&cfg, ranges[0]))
+	s.Equal([]int{7, 8, 9, 10}, s.walkRange(&cfg, ranges[1]))
+}
+
+func (s *paginatorSuite) TestPartitionSampledKey() {
+	s.givenOrders(10)
+
+	cfg := Config{
+		Keys:  []string{"CreatedAt", "ID"},
+		Limit: 3,
+		Order: ASC,
+	}
+
+	var orders []TestOrder
+	ranges, err := New(&cfg).Partition(s.db, &orders, 4)
+	s.Nil(err)
+	s.Len(ranges, 4)
+	s.Equal([]int{1, 2}, s.walkRange(&cfg, ranges[0]))
+	s.assertPartitioned(&cfg, ranges, 10)
+}
+
+func (s *paginatorSuite) TestPartitionMoreRangesThanRows() {
+	s.givenOrders(2)
+
+	cfg := Config{
+		Keys: []string{"CreatedAt", "ID"},
+	}
+
+	var orders []TestOrder
+	ranges, err := New(&cfg).Partition(s.db, &orders, 5)
+	s.Nil(err)
+	s.Len(ranges, 2)
+	s.assertPartitioned(&cfg, ranges, 2)
+}
+
+func (s *paginatorSuite) TestPartitionEmptyTable() {
+	var orders []TestOrder
+	ranges, err := New().Partition(s.db, &orders, 3)
+	s.Nil(err)
+	s.Equal([]Range{{}}, ranges)
+}
+
+func (s *paginatorSuite) TestPartitionSubquery() {
+	s.givenOrders(6)
+
+	cfg := Config{
+		Limit:    2,
+		Subquery: true,
+	}
+
+	var orders []TestOrder
+	ranges, err := New(&cfg).Partition(s.db.Model(&TestOrder{}), &orders, 2)
+	s.Nil(err)
+	s.Len(ranges, 2)
+
+	var p1 []TestOrder
+	_, c, _ := New(
+		&cfg,
+		WithUntil(*ranges[0].Until),
+	).Paginate(s.db.Model(&TestOrder{}), &p1)
+	s.assertIDRange(p1, 6, 5)
+
+	var p2 []TestOrder
+	_, c, _ = New(
+		&cfg,
+		WithAfter(*c.After),
+		WithUntil(*ranges[0].Until),
+	).Paginate(s.db.Model(&TestOrder{}), &p2)
+	s.assertIDs(p2, 4)
+	s.assertBackwardOnly(c)
+}
+
+func (s *paginatorSuite) TestPaginateUntilInvalidCursor() {
+	var orders []TestOrder
+	_, _, err := New(
+		WithUntil("invalid cursor"),
+	).Paginate(s.db, &orders)
+	s.True(errors.Is(err, ErrInvalidCursor))
+}
@@ -0,0 +1,140 @@
+package paginator
+
+import "errors"
+
+/* fixtures */
+
+func (s *paginatorSuite) givenRankedOrders() {
+	remark := func(s string) *string {
+		return &s
+	}
+	s.givenOrders([]TestOrder{
+		{ID: 1, Remark: remark("closed")},
+		{ID: 2, Remark: remark("urgent")},
+		{ID: 3, Remark: remark("open")},
+		{ID: 4},
+		{ID: 5, Remark: remark("urgent")},
+		{ID: 6, Remark: remark("pending")},
+	})
+}
+
+/* rank */
+
+func (s *paginatorSuite) TestPaginateRankedValues() {
+	s.givenRankedOrders()
+
+	cfg := Config{
+		Rules: []Rule{
+			{Key: "Remark", Values: []interface{}{"urgent", "open", "pending", "closed"}},
+			{Key: "ID"},
+		},
+		Limit: 2,
+		Order: ASC,
+	}
+
+	var p1 []TestOrder
+	_, c, err := New(&cfg).Paginate(s.db, &p1)
+	s.Nil(err)
+	s.assertIDs(p1, 2, 5)
+
+	var p2 []TestOrder
+	_, c, err = New(&cfg, WithAfter(*c.After)).Paginate(s.db, &p2)
+	s.Nil(err)
+	s.assertIDs(p2, 3, 6)
+
+	// unlisted values rank last
+	var p3 []TestOrder
+	_, c, err = New(&cfg, WithAfter(*c.After)).Paginate(s.db, &p3)
+	s.Nil(err)
+	s.assertIDs(p3, 1, 4)
+	s.assertBackwardOnly(c)
+
+	var p4 []TestOrder
+	_, _, err = New(&cfg, WithBefore(*c.Before)).Paginate(s.db, &p4)
+	s.Nil(err)
+	s.assertIDs(p4, 3, 6)
+}
+
+func (s *paginatorSuite) TestPaginateRankedValuesDesc() {
+	s.givenRankedOrders()
+
+	cfg := Config{
+		Rules: []Rule{
+			{Key: "Remark", Values: []interface{}{"urgent", "open", "pending", "closed"}},
+			{Key: "ID"},
+		},
+		Limit: 4,
+	}
+
+	var p1 []TestOrder
+	_, c, _ := New(&cfg).Paginate(s.db, &p1)
+	s.assertIDs(p1, 4, 1, 6, 3)
+
+	var p2 []TestOrder
+	_, _, _ = New(&cfg, WithAfter(*c.After)).Paginate(s.db, &p2)
+	s.assertIDs(p2, 5, 2)
+}
+
+func (s *paginatorSuite) TestPageLinksRankedValues() {
+	s.givenRankedOrders()
+
+	cfg := Config{
+		Rules: []Rule{
+			{Key: "Remark", Values: []interface{}{"urgent", "open", "pending", "closed"}},
+			{Key: "ID"},
+		},
+		Limit: 2,
+		Order: ASC,
+	}
+
+	var orders []TestOrder
+	links, err := New(&cfg).PageLinks(s.db, &orders, 3)
+	s.Nil(err)
+	s.Len(links, 2)
+
+	var p3 []TestOrder
+	_, _, _ = New(&cfg, WithAfter(links[1])).Paginate(s.db, &p3)
+	s.assertIDs(p3, 1, 4)
+}
+
+func (s *paginatorSuite) TestPaginateRankedValuesBound() {
+	remark := "it's ?"
+	s.givenOrders([]TestOrder{{ID: 1}, {ID: 2, Remark: &remark}})
+
+	// values are bound as args instead of inlined into SQL
+	var orders []TestOrder
+	_, _, err := New(
+		WithRules(Rule{Key: "Remark", Values: []interface{}{remark}}, Rule{Key: "ID"}),
+		WithOrder(ASC),
+	).Paginate(s.db, &orders)
+	s.Nil(err)
+	s.assertIDs(orders, 2, 1)
+}
+
+func (s *paginatorSuite) TestPaginateRankedIntegerValues() {
+	s.givenOrders(3)
+
+	var orders []TestOrder
+	_, _, err := New(
+		WithRules(Rule{Key: "ID", Values: []interface{}{2.0, uint8(3)}}),
+		WithOrder(ASC),
+	).Paginate(s.db, &orders)
+	s.Nil(err)
+	s.assertIDs(orders, 2, 3, 1)
+}
+
+func (s *paginatorSuite) TestPaginateInvalidRankedValues() {
+	var orders []TestOrder
+	_, _, err := New(
+		WithRules(Rule{Key: "Remark", Values: []interface{}{1, 2}}),
+	).Paginate(s.db, &orders)
+	s.True(errors.Is(err, ErrInvalidRuleValues))
+
+	// numbers not exact for integer keys would rank truncated keys
+	for _, v := range []interface{}{1.5, 1e300} {
+		_, _, err = New(
+			WithRules(Rule{Key: "ID", Values: []interface{}{v}}),
+		).Paginate(s.db, &orders)
+		s.True(errors.Is(err, ErrInvalidRuleValues), v)
+	}
+}
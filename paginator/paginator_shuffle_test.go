@@ -0,0 +1,156 @@
+package paginator
+
+import (
+	"errors"
+	"math/big"
+	"reflect"
+	"sort"
+)
+
+/* shuffle */
+
+func (s *paginatorSuite) TestPaginateShuffle() {
+	s.givenOrders(20)
+
+	ids := s.walkShuffle(WithSeed("alpha"))
+	s.Len(ids, 20)
+	seen := make(map[int]bool)
+	for _, id := range ids {
+		s.False(seen[id])
+		seen[id] = true
+	}
+	s.False(sort.IntsAreSorted(ids))
+}
+
+func (s *paginatorSuite) TestPaginateShuffleSeed() {
+	s.givenOrders(20)
+
+	s.Equal(s.walkShuffle(WithSeed("alpha")), s.walkShuffle(WithSeed("alpha")))
+	s.NotEqual(s.walkShuffle(WithSeed("alpha")), s.walkShuffle(WithSeed("beta")))
+}
+
+func (s *paginatorSuite) TestPaginateShuffleSeedFromCursor() {
+	s.givenOrders(20)
+
+	cfg := Config{
+		Rules: []Rule{{Key: "ID", Shuffle: true}},
+		Limit: 5,
+	}
+
+	var p1 []TestOrder
+	_, c, err := New(&cfg).Paginate(s.db, &p1)
+	s.Nil(err)
+	s.Len(p1, 5)
+
+	// later pages continue shuffle of the generated seed
+	var p2 []TestOrder
+	_, c, err = New(&cfg, WithAfter(*c.After)).Paginate(s.db, &p2)
+	s.Nil(err)
+	s.Len(p2, 5)
+
+	var p1Again []TestOrder
+	_, _, err = New(&cfg, WithBefore(*c.Before)).Paginate(s.db, &p1Again)
+	s.Nil(err)
+	s.Equal(p1, p1Again)
+}
+
+func (s *paginatorSuite) TestPaginateShuffleHashCollision() {
+	// h and m - h collide in the sqlite hash, rows of equal hashes are ordered by raw key
+	r := Rule{seed: formatSeed("alpha"), dialect: "sqlite3"}
+	h := (shuffleMultiplier + r.intSeed()) % shuffleModulus
+	m := big.NewInt(shuffleModulus)
+	id := new(big.Int).ModInverse(big.NewInt(shuffleMultiplier), m)
+	id.Mul(id, big.NewInt(2*shuffleModulus-h-r.intSeed())).Mod(id, m)
+	s.Require().Equal(r.shuffleHash(reflect.ValueOf(1)), r.shuffleHash(reflect.ValueOf(int(id.Int64()))))
+
+	s.givenOrders([]TestOrder{{ID: 1}, {ID: int(id.Int64())}})
+
+	ids := s.walkShuffle(WithSeed("alpha"), WithLimit(1))
+	s.ElementsMatch([]int{1, int(id.Int64())}, ids)
+}
+
+func (s *paginatorSuite) TestPaginateShuffleInvalidSeed() {
+	s.givenOrders(3)
+
+	// seed is the last value of cursor, after hash and raw key of shuffle rule
+	c, err := newCursorCodec([]reflect.Type{
+		reflect.TypeOf(""), reflect.TypeOf(0), reflect.TypeOf(""),
+	}, nil).encode([]interface{}{"0", 1, "' OR 1=1 -- "})
+	s.Require().Nil(err)
+
+	var orders []TestOrder
+	_, _, err = New(
+		WithRules(Rule{Key: "ID", Shuffle: true}),
+		WithAfter(c),
+	).Paginate(s.db, &orders)
+	var e *CursorError
+	s.Require().True(errors.As(err, &e))
+	s.Equal(2, e.Position)
+	s.True(errors.Is(err, ErrInvalidCursor))
+}
+
+func (s *paginatorSuite) TestPageLinksShuffle() {
+	s.givenOrders(9)
+
+	ids := s.walkShuffle(WithSeed("alpha"))
+
+	var orders []TestOrder
+	links, err := New(
+		WithRules(Rule{Key: "ID", Shuffle: true}),
+		WithLimit(3),
+		WithSeed("alpha"),
+	).PageLinks(s.db, &orders, 2)
+	s.Nil(err)
+	s.Len(links, 2)
+
+	// links carry seed, so pages are taken from the same shuffle
+	var p3 []TestOrder
+	_, _, err = New(
+		WithRules(Rule{Key: "ID", Shuffle: true}),
+		WithLimit(3),
+		WithAfter(links[1]),
+	).Paginate(s.db, &p3)
+	s.Nil(err)
+	s.assertIDs(p3, ids[6:]...)
+}
+
+func (s *paginatorSuite) TestPaginateInvalidShuffleRule() {
+	var orders []TestOrder
+	_, _, err := New(
+		WithRules(Rule{Key: "CreatedAt", Shuffle: true}),
+	).Paginate(s.db, &orders)
+	s.True(errors.Is(err, ErrInvalidShuffleRule))
+
+	// NULL has no hash
+	s.givenOrders([]TestOrder{{}})
+	_, _, err = New(
+		WithRules(Rule{Key: "Remark", Shuffle: true}),
+	).Paginate(s.db, &orders)
+	s.True(errors.Is(err, ErrInvalidShuffleRule))
+}
+
+/* util */
+
+func (s *paginatorSuite) walkShuffle(opts ...Option) (ids []int) {
+	opts = append([]Option{
+		WithRules(Rule{Key: "ID", Shuffle: true}),
+		WithLimit(3),
+	}, opts...)
+	var after *string
+	for {
+		o := opts
+		if after != nil {
+			o = append(o[:len(o):len(o)], WithAfter(*after))
+		}
+		var orders []TestOrder
+		_, c, err := New(o...).Paginate(s.db, &orders)
+		s.Require().Nil(err)
+		for _, order := range orders {
+			ids = append(ids, order.ID)
+		}
+		if c.After == nil {
+			return
+		}
+		after = c.After
+	}
+}
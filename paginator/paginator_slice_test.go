@@ -0,0 +1,228 @@
+package paginator
+
+/* slice */
+
+func (s *paginatorSuite) TestPaginateSlice() {
+	var orders []TestOrder
+	for _, id := range []int{3, 1, 5, 2, 4} {
+		orders = append(orders, TestOrder{ID: id})
+	}
+	cfg := Config{
+		Keys:  []string{"ID"},
+		Limit: 2,
+	}
+
+	var p1 []TestOrder
+	c, err := New(&cfg).PaginateSlice(orders, &p1)
+	s.Nil(err)
+	s.assertIDs(p1, 5, 4)
+	s.assertForwardOnly(c)
+
+	var p2 []TestOrder
+	c, err = New(&cfg, WithAfter(*c.After)).PaginateSlice(orders, &p2)
+	s.Nil(err)
+	s.assertIDs(p2, 3, 2)
+	s.assertBothDirections(c)
+
+	var p3 []TestOrder
+	c, err = New(&cfg, WithAfter(*c.After)).PaginateSlice(orders, &p3)
+	s.Nil(err)
+	s.assertIDs(p3, 1)
+	s.assertBackwardOnly(c)
+
+	var p2Again []TestOrder
+	c, err = New(&cfg, WithBefore(*c.Before)).PaginateSlice(orders, &p2Again)
+	s.Nil(err)
+	s.assertIDs(p2Again, 3, 2)
+	s.assertBothDirections(c)
+
+	var p1Again []TestOrder
+	c, err = New(&cfg, WithBefore(*c.Before)).PaginateSlice(orders, &p1Again)
+	s.Nil(err)
+	s.assertIDs(p1Again, 5, 4)
+	s.assertForwardOnly(c)
+
+	// items are left untouched
+	s.assertIDs(orders, 3, 1, 5, 2, 4)
+}
+
+func (s *paginatorSuite) TestPaginateSliceInterchangeableCursor() {
+	s.givenOrders(10)
+
+	var orders []TestOrder
+	s.Nil(s.db.Find(&orders).Error)
+	cfg := Config{
+		Keys:  []string{"CreatedAt", "ID"},
+		Limit: 3,
+		Order: ASC,
+	}
+
+	// cursor of statement continues on slice
+	var p1 []TestOrder
+	_, c, _ := New(&cfg).Paginate(s.db, &p1)
+	var p2 []TestOrder
+	c, err := New(&cfg, WithAfter(*c.After)).PaginateSlice(orders, &p2)
+	s.Nil(err)
+	s.assertIDs(p2, 4, 5, 6)
+
+	// and cursor of slice continues on statement
+	var p3 []TestOrder
+	_, c, err = New(&cfg, WithAfter(*c.After)).Paginate(s.db, &p3)
+	s.Nil(err)
+	s.assertIDs(p3, 7, 8, 9)
+
+	var p2Again []TestOrder
+	_, err = New(&cfg, WithBefore(*c.Before)).PaginateSlice(orders, &p2Again)
+	s.Nil(err)
+	s.assertIDs(p2Again, 4, 5, 6)
+}
+
+func (s *paginatorSuite) TestPaginateSliceDialect() {
+	remark := func(r string) *string { return &r }
+	s.givenOrders([]TestOrder{
+		{Remark: remark("b")},
+		{},
+		{Remark: remark("B")},
+		{Remark: remark("a")},
+		{},
+	})
+
+	var orders []TestOrder
+	s.Nil(s.db.Find(&orders).Error)
+
+	// slice follows order of dialect on strings, NULL and hashes of shuffle rules
+	for _, rules := range [][]Rule{
+		{{Key: "Remark", Collation: s.byteOrderCollation()}, {Key: "ID"}},
+		{{Key: "ID", Shuffle: true}},
+	} {
+		cfg := Config{
+			Rules: rules,
+			Limit: 10,
+			Order: ASC,
+			Seed:  "alpha",
+		}
+		var p1 []TestOrder
+		_, _, err := New(&cfg).Paginate(s.db, &p1)
+		s.Require().Nil(err)
+		var ids []int
+		for _, order := range p1 {
+			ids = append(ids, order.ID)
+		}
+
+		var p2 []TestOrder
+		_, err = New(&cfg, WithSliceDialect(s.db.Dialect().GetName())).PaginateSlice(orders, &p2)
+		s.Nil(err)
+		s.assertIDs(p2, ids...)
+	}
+}
+
+func (s *paginatorSuite) TestPaginateSliceUntil() {
+	var orders []TestOrder
+	for id := 1; id <= 5; id++ {
+		orders = append(orders, TestOrder{ID: id})
+	}
+	cfg := Config{
+		Keys:  []string{"ID"},
+		Limit: 2,
+		Order: ASC,
+	}
+
+	var p1 []TestOrder
+	c, _ := New(&cfg).PaginateSlice(orders, &p1)
+
+	var all []TestOrder
+	_, err := New(&cfg, WithLimit(10), WithUntil(*c.After)).PaginateSlice(orders, &all)
+	s.Nil(err)
+	s.assertIDs(all, 1, 2)
+}
+
+func (s *paginatorSuite) TestPaginateSliceSnapshot() {
+	var orders []TestOrder
+	for id := 1; id <= 3; id++ {
+		orders = append(orders, TestOrder{ID: id})
+	}
+	cfg := Config{
+		Keys:     []string{"ID"},
+		Limit:    2,
+		Order:    ASC,
+		Snapshot: "ID",
+	}
+
+	var p1 []TestOrder
+	c, _ := New(&cfg).PaginateSlice(orders, &p1)
+	s.assertIDs(p1, 1, 2)
+
+	// rows beyond snapshot bound are ignored
+	orders = append(orders, TestOrder{ID: 4})
+	var p2 []TestOrder
+	c, err := New(&cfg, WithAfter(*c.After)).PaginateSlice(orders, &p2)
+	s.Nil(err)
+	s.assertIDs(p2, 3)
+	s.assertBackwardOnly(c)
+}
+
+func (s *paginatorSuite) TestPaginateSliceSnapshotWithoutBound() {
+	s.givenOrders(3)
+
+	var orders []TestOrder
+	s.Nil(s.db.Find(&orders).Error)
+	// snapshot key is NULL on every row, so there is no bound
+	cfg := Config{
+		Keys:     []string{"ID"},
+		Limit:    2,
+		Order:    ASC,
+		Snapshot: "Remark",
+	}
+
+	var p1 []TestOrder
+	c, err := New(&cfg).PaginateSlice(orders, &p1)
+	s.Nil(err)
+	s.assertIDs(p1, 1, 2)
+
+	// rows are not bounded, like statements do
+	var p2 []TestOrder
+	_, c2, err := New(&cfg).Paginate(s.db, &p2)
+	s.Nil(err)
+	s.assertIDs(p2, 1, 2)
+
+	var p3 []TestOrder
+	_, err = New(&cfg, WithAfter(*c2.After)).PaginateSlice(orders, &p3)
+	s.Nil(err)
+	s.assertIDs(p3, 3)
+
+	var p4 []TestOrder
+	_, _, err = New(&cfg, WithAfter(*c.After)).Paginate(s.db, &p4)
+	s.Nil(err)
+	s.assertIDs(p4, 3)
+}
+
+func (s *paginatorSuite) TestPaginateSliceInvalidModel() {
+	var orders []TestOrder
+	_, err := New(WithKeys("ID"), WithLimit(1)).PaginateSlice([]TestItem{}, &orders)
+	s.Equal(ErrInvalidModel, err)
+}
+
+func (s *paginatorSuite) TestPaginateSliceInvalidSliceRule() {
+	var orders []TestOrder
+	_, err := New(
+		WithRules(Rule{Key: "Remark", Collation: s.orderingCollation()}),
+		WithLimit(1),
+	).PaginateSlice([]TestOrder{}, &orders)
+	s.Equal(ErrInvalidSliceRule, err)
+
+	// default collation of postgres is not known to order by bytes
+	_, err = New(
+		WithRules(Rule{Key: "Remark"}),
+		WithLimit(1),
+	).PaginateSlice([]TestOrder{}, &orders)
+	s.Equal(ErrInvalidSliceRule, err)
+}
+
+func (s *paginatorSuite) TestPaginateSliceUnsupportedDialect() {
+	var orders []TestOrder
+	_, err := New(
+		WithKeys("ID"),
+		WithSliceDialect("mssql"),
+	).PaginateSlice([]TestOrder{}, &orders)
+	s.Equal(ErrUnsupportedDialect, err)
+}
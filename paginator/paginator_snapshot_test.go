@@ -0,0 +1,106 @@
+package paginator
+
+import (
+	"errors"
+	"time"
+)
+
+func (s *paginatorSuite) TestPaginateSnapshot() {
+	s.givenOrders(5)
+
+	cfg := Config{
+		Limit:    2,
+		Snapshot: "ID",
+	}
+
+	var p1 []TestOrder
+	_, c, _ := New(&cfg).Paginate(s.db, &p1)
+	s.assertIDRange(p1, 5, 4)
+	s.assertForwardOnly(c)
+
+	// orders created after scrolling started
+	s.givenOrders(2)
+
+	var p2 []TestOrder
+	_, c, _ = New(
+		&cfg,
+		WithAfter(*c.After),
+	).Paginate(s.db, &p2)
+	s.assertIDRange(p2, 3, 2)
+	s.assertBothDirections(c)
+
+	var p3 []TestOrder
+	_, c, _ = New(
+		&cfg,
+		WithBefore(*c.Before),
+	).Paginate(s.db, &p3)
+	s.assertIDRange(p3, 5, 4)
+	s.assertForwardOnly(c)
+}
+
+func (s *paginatorSuite) TestPaginateSnapshotByAnotherKey() {
+	now := time.Now()
+	s.givenOrders([]TestOrder{
+		{ID: 1, CreatedAt: now.Add(-2 * time.Hour)},
+		{ID: 2, CreatedAt: now.Add(-1 * time.Hour)},
+		{ID: 3, CreatedAt: now},
+	})
+
+	cfg := Config{
+		Keys:     []string{"ID"},
+		Limit:    2,
+		Order:    ASC,
+		Snapshot: "CreatedAt",
+	}
+
+	var p1 []TestOrder
+	_, c, _ := New(&cfg).Paginate(s.db, &p1)
+	s.assertIDRange(p1, 1, 2)
+	s.assertForwardOnly(c)
+
+	// order 3 is touched after scrolling started
+	s.db.Model(&TestOrder{ID: 3}).Update("created_at", now.Add(time.Hour))
+
+	var p2 []TestOrder
+	_, c, _ = New(
+		&cfg,
+		WithAfter(*c.After),
+	).Paginate(s.db, &p2)
+	s.Len(p2, 0)
+	s.assertNoMore(c)
+}
+
+func (s *paginatorSuite) TestPaginateSnapshotEmptyTable() {
+	var p1 []TestOrder
+	_, c, err := New(
+		WithSnapshot("ID"),
+	).Paginate(s.db, &p1)
+	s.Nil(err)
+	s.Len(p1, 0)
+	s.assertNoMore(c)
+}
+
+func (s *paginatorSuite) TestPaginateSnapshotCursorWithoutBound() {
+	s.givenOrders(3)
+
+	var p1 []TestOrder
+	_, c, _ := New(
+		WithLimit(2),
+	).Paginate(s.db, &p1)
+
+	var p2 []TestOrder
+	_, _, err := New(
+		WithLimit(2),
+		WithSnapshot("ID"),
+		WithAfter(*c.After),
+	).Paginate(s.db, &p2)
+	s.True(errors.Is(err, ErrInvalidCursor))
+}
+
+func (s *paginatorSuite) TestPaginateSnapshotInvalidKey() {
+	var orders []TestOrder
+	_, _, err := New(
+		WithSnapshot("UnknownKey"),
+	).Paginate(s.db, &orders)
+	s.True(errors.Is(err, ErrInvalidModel))
+}
@@ -0,0 +1,76 @@
+package paginator
+
+import (
+	"errors"
+
+	"github.com/hashicorp/gorm-cursor-paginator/cursor"
+)
+
+/* strict cursor */
+
+func (s *paginatorSuite) TestPaginateStrictCursor() {
+	s.givenOrders(5)
+
+	cfg := Config{
+		Keys:         []string{"ID"},
+		Limit:        2,
+		Snapshot:     "ID",
+		StrictCursor: &cursor.Limits{MaxLength: 64},
+	}
+
+	// cursors carrying snapshot bound are decoded strictly as well
+	var p1 []TestOrder
+	_, c, err := New(&cfg).Paginate(s.db, &p1)
+	s.Nil(err)
+	s.assertIDs(p1, 5, 4)
+
+	var p2 []TestOrder
+	_, _, err = New(&cfg, WithAfter(*c.After)).Paginate(s.db, &p2)
+	s.Nil(err)
+	s.assertIDs(p2, 3, 2)
+}
+
+func (s *paginatorSuite) TestPaginateStrictCursorElementCount() {
+	s.givenOrders(3)
+
+	var p1 []TestOrder
+	_, c, _ := New(WithKeys("ID", "CreatedAt"), WithLimit(1)).Paginate(s.db, &p1)
+
+	// extra values are ignored unless decoding strictly
+	var p2 []TestOrder
+	_, _, err := New(WithKeys("ID"), WithAfter(*c.After)).Paginate(s.db, &p2)
+	s.Nil(err)
+
+	_, _, err = New(
+		WithKeys("ID"),
+		WithAfter(*c.After),
+		WithStrictCursor(cursor.Limits{}),
+	).Paginate(s.db, &p2)
+	var e *CursorError
+	s.Require().True(errors.As(err, &e))
+	s.Equal(1, e.Position)
+	s.True(errors.Is(err, ErrInvalidCursor))
+	s.True(errors.Is(err, cursor.ErrElementCount))
+}
+
+func (s *paginatorSuite) TestPaginateStrictCursorLimits() {
+	var orders []TestOrder
+	c, _ := cursor.NewEncoder("Remark").Encode(TestOrder{Remark: func(s string) *string { return &s }("too long remark")})
+
+	_, _, err := New(
+		WithKeys("Remark"),
+		WithAfter(c),
+		WithStrictCursor(cursor.Limits{MaxStringSize: 8}),
+	).Paginate(s.db, &orders)
+	var e *CursorError
+	s.Require().True(errors.As(err, &e))
+	s.Equal("Remark", e.Key)
+	s.True(errors.Is(err, cursor.ErrStringTooLong))
+
+	_, _, err = New(
+		WithKeys("Remark"),
+		WithAfter(c),
+		WithStrictCursor(cursor.Limits{MaxLength: len(c) - 1}),
+	).Paginate(s.db, &orders)
+	s.True(errors.Is(err, cursor.ErrCursorTooLong))
+}
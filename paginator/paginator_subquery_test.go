@@ -0,0 +1,102 @@
+package paginator
+
+func (s *paginatorSuite) TestPaginateSubqueryUnion() {
+	s.givenOrders(5)
+
+	stmt := s.db.Raw(
+		"SELECT * FROM orders WHERE id <= ? UNION SELECT * FROM orders WHERE id > ?",
+		2,
+		3,
+	)
+
+	cfg := Config{
+		Limit:    2,
+		Subquery: true,
+	}
+
+	var p1 []TestOrder
+	_, c, err := New(&cfg).Paginate(stmt, &p1)
+	s.Nil(err)
+	s.assertIDs(p1, 5, 4)
+	s.assertForwardOnly(c)
+
+	var p2 []TestOrder
+	_, c, err = New(
+		&cfg,
+		WithAfter(*c.After),
+	).Paginate(stmt, &p2)
+	s.Nil(err)
+	s.assertIDs(p2, 2, 1)
+	s.assertBackwardOnly(c)
+
+	var p3 []TestOrder
+	_, c, err = New(
+		&cfg,
+		WithBefore(*c.Before),
+	).Paginate(stmt, &p3)
+	s.Nil(err)
+	s.assertIDs(p3, 5, 4)
+	s.assertForwardOnly(c)
+}
+
+func (s *paginatorSuite) TestPaginateSubqueryDistinct() {
+	for i, n := range []int{2, 1, 3} {
+		order := s.givenOrders([]TestOrder{{ID: i + 1}})[0]
+		s.givenItems(order, n)
+	}
+
+	type orderRef struct {
+		OrderID int
+	}
+
+	stmt := s.db.Table("items").Select("DISTINCT items.order_id")
+
+	cfg := Config{
+		Keys:     []string{"OrderID"},
+		Limit:    2,
+		Order:    ASC,
+		Subquery: true,
+	}
+
+	var p1 []orderRef
+	_, c, err := New(&cfg).Paginate(stmt, &p1)
+	s.Nil(err)
+	s.Equal([]orderRef{{1}, {2}}, p1)
+	s.assertForwardOnly(c)
+
+	var p2 []orderRef
+	_, c, err = New(
+		&cfg,
+		WithAfter(*c.After),
+	).Paginate(stmt, &p2)
+	s.Nil(err)
+	s.Equal([]orderRef{{3}}, p2)
+	s.assertBackwardOnly(c)
+}
+
+func (s *paginatorSuite) TestPaginateSubqueryWithSnapshot() {
+	s.givenOrders(3)
+
+	stmt := s.db.Model(&TestOrder{})
+
+	cfg := Config{
+		Limit:    2,
+		Snapshot: "ID",
+		Subquery: true,
+	}
+
+	var p1 []TestOrder
+	_, c, _ := New(&cfg).Paginate(stmt, &p1)
+	s.assertIDs(p1, 3, 2)
+
+	s.givenOrders(1)
+
+	var p2 []TestOrder
+	_, c, err := New(
+		&cfg,
+		WithAfter(*c.After),
+	).Paginate(stmt, &p2)
+	s.Nil(err)
+	s.assertIDs(p2, 1)
+	s.assertBackwardOnly(c)
+}
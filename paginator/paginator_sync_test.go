@@ -0,0 +1,123 @@
+package paginator
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+/* fixtures */
+
+// givenChanges creates changes updated in the past one second apart, so that
+// changes made later by tests are always after them
+func (s *paginatorSuite) givenChanges(n int) (changes []TestChange) {
+	past := time.Now().UTC().Truncate(time.Second).Add(-time.Hour)
+	for i := 0; i < n; i++ {
+		change := TestChange{
+			Name:      fmt.Sprintf("change %d", i+1),
+			UpdatedAt: past.Add(time.Duration(i) * time.Second),
+		}
+		if err := s.db.Create(&change).Error; err != nil {
+			panic(err.Error())
+		}
+		changes = append(changes, change)
+	}
+	return
+}
+
+/* sync */
+
+func (s *paginatorSuite) TestSync() {
+	s.givenChanges(3)
+
+	p := New(
+		WithKeys("UpdatedAt", "ID"),
+		WithLimit(2),
+	)
+
+	var p1 []TestChange
+	_, token, hasMore, err := p.Sync(s.db, &p1, "")
+	s.Nil(err)
+	s.assertIDRange(p1, 1, 2)
+	s.True(hasMore)
+
+	var p2 []TestChange
+	_, token, hasMore, _ = p.Sync(s.db, &p2, token)
+	s.assertIDRange(p2, 3, 3)
+	s.False(hasMore)
+
+	// token is resumable even when there is no change
+	var p3 []TestChange
+	_, next, hasMore, _ := p.Sync(s.db, &p3, token)
+	s.Len(p3, 0)
+	s.False(hasMore)
+	s.Equal(token, next)
+
+	// change again after synced
+	s.db.Model(&TestChange{ID: 1}).Update("name", "change 1 again")
+
+	var p4 []TestChange
+	_, token, _, _ = p.Sync(s.db, &p4, next)
+	s.assertIDs(p4, 1)
+	s.Equal("change 1 again", p4[0].Name)
+
+	var p5 []TestChange
+	_, _, _, _ = p.Sync(s.db, &p5, token)
+	s.Len(p5, 0)
+}
+
+func (s *paginatorSuite) TestSyncEmpty() {
+	var changes []TestChange
+	_, token, hasMore, err := New(
+		WithKeys("UpdatedAt", "ID"),
+	).Sync(s.db, &changes, "")
+	s.Nil(err)
+	s.Len(changes, 0)
+	s.False(hasMore)
+	s.Equal("", token)
+}
+
+func (s *paginatorSuite) TestSyncShouldIgnoreOrder() {
+	s.givenChanges(3)
+
+	var changes []TestChange
+	_, _, _, _ = New(
+		WithKeys("UpdatedAt", "ID"),
+		WithOrder(DESC),
+	).Sync(s.db, &changes, "")
+	s.assertIDRange(changes, 1, 3)
+}
+
+func (s *paginatorSuite) TestSyncTombstone() {
+	s.givenChanges(3)
+
+	p := New(
+		WithKeys("UpdatedAt", "ID"),
+	)
+
+	var p1 []TestChange
+	_, token, _, _ := p.Sync(s.db, &p1, "")
+	s.assertIDRange(p1, 1, 3)
+
+	// soft delete only touches deleted_at, which is set after every change here
+	deletedAt := p1[2].UpdatedAt.Add(time.Second)
+	s.db.Model(&TestChange{ID: 2}).UpdateColumn("deleted_at", deletedAt)
+
+	var p2 []TestChange
+	_, token, _, err := p.Sync(s.db, &p2, token)
+	s.Nil(err)
+	s.assertIDs(p2, 2)
+	s.NotNil(p2[0].DeletedAt)
+
+	var p3 []TestChange
+	_, _, _, _ = p.Sync(s.db, &p3, token)
+	s.Len(p3, 0)
+}
+
+func (s *paginatorSuite) TestSyncInvalidToken() {
+	var changes []TestChange
+	_, _, _, err := New(
+		WithKeys("UpdatedAt", "ID"),
+	).Sync(s.db, &changes, "invalid token")
+	s.True(errors.Is(err, ErrInvalidCursor))
+}
@@ -40,6 +40,17 @@ func (i TestItem) TableName() string {
 	return "items"
 }
 
+type TestChange struct {
+	ID        int        `gorm:"primary_key"`
+	Name      string     `gorm:"type:varchar(30)"`
+	UpdatedAt time.Time  `gorm:"type:timestamp;not null"`
+	DeletedAt *time.Time `gorm:"type:timestamp"`
+}
+
+func (c TestChange) TableName() string {
+	return "changes"
+}
+
 /* paginator suite */
 
 type paginatorSuite struct {
@@ -55,18 +66,18 @@ func (s *paginatorSuite) SetupSuite() {
 		s.FailNow(err.Error())
 	}
 	s.db = db
-	s.db.AutoMigrate(&TestOrder{}, &TestItem{})
+	s.db.AutoMigrate(&TestOrder{}, &TestItem{}, &TestChange{})
 	s.db.Model(&TestItem{}).AddForeignKey("order_id", "orders(id)", "CASCADE", "CASCADE")
 }
 
 /* teardown */
 
 func (s *paginatorSuite) TearDownTest() {
-	s.db.Exec("TRUNCATE orders, items RESTART IDENTITY;")
+	s.db.Exec("TRUNCATE orders, items, changes RESTART IDENTITY;")
 }
 
 func (s *paginatorSuite) TearDownSuite() {
-	s.db.DropTable(&TestItem{}, &TestOrder{})
+	s.db.DropTable(&TestItem{}, &TestOrder{}, &TestChange{})
 	s.db.Close()
 }
 
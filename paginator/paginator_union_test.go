@@ -0,0 +1,118 @@
+package paginator
+
+import (
+	"fmt"
+	"time"
+)
+
+/* fixtures */
+
+// givenFeed creates orders and changes alternating by hour, starting from an order
+func (s *paginatorSuite) givenFeed(n int) {
+	start := time.Now().Truncate(time.Second)
+	for i := 0; i < n; i++ {
+		s.givenOrders([]TestOrder{{CreatedAt: start.Add(time.Duration(2*i) * time.Hour)}})
+		change := s.givenChanges(1)[0]
+		s.db.Model(&change).UpdateColumn("updated_at", start.Add(time.Duration(2*i+1)*time.Hour))
+	}
+}
+
+/* union */
+
+func (s *paginatorSuite) TestPaginateUnion() {
+	s.givenFeed(3)
+
+	sources := []Source{
+		{Name: "order", DB: s.db, Model: &[]TestOrder{}},
+		{Name: "change", DB: s.db, Model: &[]TestChange{}, Rules: []Rule{{Key: "UpdatedAt"}, {Key: "ID"}}},
+	}
+	cfg := Config{
+		Keys:  []string{"CreatedAt", "ID"},
+		Limit: 4,
+		Order: ASC,
+	}
+
+	p1, c, err := New(&cfg).PaginateUnion(sources)
+	s.Nil(err)
+	s.assertFeed(p1, "order 1", "change 1", "order 2", "change 2")
+	s.assertForwardOnly(c)
+
+	p2, c, err := New(&cfg, WithAfter(*c.After)).PaginateUnion(sources)
+	s.Nil(err)
+	s.assertFeed(p2, "order 3", "change 3")
+	s.assertBackwardOnly(c)
+
+	p1Again, c, err := New(&cfg, WithBefore(*c.Before)).PaginateUnion(sources)
+	s.Nil(err)
+	s.assertFeed(p1Again, "order 1", "change 1", "order 2", "change 2")
+	s.assertForwardOnly(c)
+}
+
+func (s *paginatorSuite) TestPaginateUnionDesc() {
+	s.givenFeed(3)
+
+	sources := []Source{
+		{Name: "order", DB: s.db, Model: &[]TestOrder{}},
+		{Name: "change", DB: s.db.Where("id <> ?", 3), Model: &[]TestChange{}, Rules: []Rule{{Key: "UpdatedAt"}, {Key: "ID"}}},
+	}
+	cfg := Config{
+		Keys:  []string{"CreatedAt", "ID"},
+		Limit: 2,
+	}
+
+	p1, c, _ := New(&cfg).PaginateUnion(sources)
+	s.assertFeed(p1, "order 3", "change 2")
+
+	p2, c, _ := New(&cfg, WithAfter(*c.After)).PaginateUnion(sources)
+	s.assertFeed(p2, "order 2", "change 1")
+
+	p3, _, _ := New(&cfg, WithAfter(*c.After)).PaginateUnion(sources)
+	s.assertFeed(p3, "order 1")
+}
+
+func (s *paginatorSuite) TestPaginateUnionInvalidMergeRule() {
+	sources := []Source{
+		{Name: "order", DB: s.db, Model: &[]TestOrder{}},
+		{Name: "change", DB: s.db, Model: &[]TestChange{}, Rules: []Rule{{Key: "UpdatedAt"}}},
+	}
+	_, _, err := New(
+		WithKeys("CreatedAt", "ID"),
+		WithLimit(1),
+	).PaginateUnion(sources)
+	s.Equal(ErrInvalidMergeRule, err)
+
+	// names cannot be merged with ids
+	sources[1].Rules = []Rule{{Key: "Name"}, {Key: "ID"}}
+	_, _, err = New(
+		WithKeys("CreatedAt", "ID"),
+		WithLimit(1),
+	).PaginateUnion(sources)
+	s.Equal(ErrInvalidMergeRule, err)
+}
+
+func (s *paginatorSuite) TestPaginateUnionInvalidModel() {
+	// model should be a pointer to slice
+	for _, model := range []interface{}{nil, TestOrder{}, &TestOrder{}, []TestOrder{}} {
+		_, _, err := New(
+			WithKeys("CreatedAt", "ID"),
+			WithLimit(1),
+		).PaginateUnion([]Source{{Name: "order", DB: s.db, Model: model}})
+		s.Equal(ErrInvalidModel, err)
+	}
+}
+
+/* util */
+
+func (s *paginatorSuite) assertFeed(items []Item, tags ...string) {
+	s.Len(items, len(tags))
+	for i, item := range items {
+		var id int
+		switch v := item.Value.(type) {
+		case TestOrder:
+			id = v.ID
+		case TestChange:
+			id = v.ID
+		}
+		s.Equal(tags[i], fmt.Sprintf("%s %d", item.Source, id))
+	}
+}
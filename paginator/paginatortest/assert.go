@@ -0,0 +1,100 @@
+// Package paginatortest provides fixtures, assertions and fakes for testing code built on paginator.
+package paginatortest
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/hashicorp/gorm-cursor-paginator/paginator"
+)
+
+/* fixtures */
+
+// GivenRecords creates each record of records slice in database, primary keys
+// generated by database are set back to records
+func GivenRecords(t assert.TestingT, db *gorm.DB, records interface{}) bool {
+	rv := reflect.ValueOf(records)
+	if rv.Kind() == reflect.Ptr {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Slice {
+		return assert.Fail(t, "GivenRecords: records should be a slice")
+	}
+	for i := 0; i < rv.Len(); i++ {
+		e := rv.Index(i)
+		if e.Kind() != reflect.Ptr {
+			e = e.Addr()
+		}
+		if err := db.Create(e.Interface()).Error; err != nil {
+			return assert.Fail(t, fmt.Sprintf("GivenRecords: %s", err))
+		}
+	}
+	return true
+}
+
+/* assertions */
+
+// AssertIDRange asserts IDs of records in result are consecutive from fromID to toID
+func AssertIDRange(t assert.TestingT, result interface{}, fromID, toID int) bool {
+	ids := []int{}
+	for id := fromID; ; {
+		ids = append(ids, id)
+		if id == toID {
+			break
+		}
+		if fromID < toID {
+			id++
+		} else {
+			id--
+		}
+	}
+	return AssertIDs(t, result, ids...)
+}
+
+// AssertIDs asserts integer IDs of records in result are exactly ids in order
+func AssertIDs(t assert.TestingT, result interface{}, ids ...int) bool {
+	rv := reflect.ValueOf(result)
+	if rv.Kind() != reflect.Slice {
+		return assert.Fail(t, "AssertIDs: result should be a slice")
+	}
+	actual := make([]int, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		id := reflect.Indirect(rv.Index(i)).FieldByName("ID")
+		switch id.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			actual[i] = int(id.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			actual[i] = int(id.Uint())
+		default:
+			return assert.Fail(t, "AssertIDs: records should have integer ID field")
+		}
+	}
+	return assert.Equal(t, append([]int{}, ids...), actual)
+}
+
+// AssertForwardOnly asserts cursor can only move forward
+func AssertForwardOnly(t assert.TestingT, c paginator.Cursor) bool {
+	return assert.NotNil(t, c.After, "after cursor") &&
+		assert.Nil(t, c.Before, "before cursor")
+}
+
+// AssertBackwardOnly asserts cursor can only move backward
+func AssertBackwardOnly(t assert.TestingT, c paginator.Cursor) bool {
+	return assert.Nil(t, c.After, "after cursor") &&
+		assert.NotNil(t, c.Before, "before cursor")
+}
+
+// AssertBothDirections asserts cursor can move both forward and backward
+func AssertBothDirections(t assert.TestingT, c paginator.Cursor) bool {
+	return assert.NotNil(t, c.After, "after cursor") &&
+		assert.NotNil(t, c.Before, "before cursor")
+}
+
+// AssertNoMore asserts cursor can move to neither direction
+func AssertNoMore(t assert.TestingT, c paginator.Cursor) bool {
+	return assert.Nil(t, c.After, "after cursor") &&
+		assert.Nil(t, c.Before, "before cursor")
+}
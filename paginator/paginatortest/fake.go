@@ -0,0 +1,122 @@
+package paginatortest
+
+import (
+	"reflect"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/hashicorp/gorm-cursor-paginator/cursor"
+	"github.com/hashicorp/gorm-cursor-paginator/paginator"
+)
+
+// Fake in-memory paginator paging through Items by position, so that tests need no database
+type Fake struct {
+	// Items is a slice of records in paging order
+	Items  interface{}
+	Limit  int
+	Cursor paginator.Cursor
+	// Err will be returned from Paginate when specified
+	Err error
+}
+
+type position struct {
+	Index int
+}
+
+// Paginate paginates Items into dest, db is returned as result untouched
+func (f *Fake) Paginate(db *gorm.DB, dest interface{}) (result *gorm.DB, c paginator.Cursor, err error) {
+	result = db
+	if f.Err != nil {
+		err = f.Err
+		return
+	}
+	if f.Limit <= 0 {
+		err = paginator.ErrInvalidLimit
+		return
+	}
+	items := reflect.ValueOf(f.Items)
+	elems := reflect.ValueOf(dest)
+	if items.Kind() != reflect.Slice ||
+		elems.Kind() != reflect.Ptr ||
+		elems.Elem().Type() != items.Type() {
+		err = paginator.ErrInvalidModel
+		return
+	}
+	elems = elems.Elem()
+	// [from, to) is the page range including one more item for checking next page
+	from, to := 0, f.Limit+1
+	if f.isForward() {
+		after, err := f.decode(*f.Cursor.After)
+		if err != nil {
+			return result, c, err
+		}
+		from, to = after+1, after+1+f.Limit+1
+	} else if f.isBackward() {
+		before, err := f.decode(*f.Cursor.Before)
+		if err != nil {
+			return result, c, err
+		}
+		from, to = before-f.Limit-1, before
+	}
+	from, to = clamp(from, items.Len()), clamp(to, items.Len())
+	hasMore := to-from > f.Limit
+	if hasMore {
+		if f.isBackward() {
+			from++
+		} else {
+			to--
+		}
+	}
+	elems.Set(reflect.AppendSlice(reflect.MakeSlice(items.Type(), 0, to-from), items.Slice(from, to)))
+	if to-from > 0 {
+		c, err = f.encodeCursor(from, to, hasMore)
+	}
+	return
+}
+
+/* private */
+
+func (f *Fake) isForward() bool {
+	return f.Cursor.After != nil
+}
+
+func (f *Fake) isBackward() bool {
+	return !f.isForward() && f.Cursor.Before != nil
+}
+
+func (f *Fake) encodeCursor(from, to int, hasMore bool) (result paginator.Cursor, err error) {
+	encoder := cursor.NewEncoder("Index")
+	if f.isBackward() || hasMore {
+		c, err := encoder.Encode(position{to - 1})
+		if err != nil {
+			return paginator.Cursor{}, err
+		}
+		result.After = &c
+	}
+	if f.isForward() || (hasMore && f.isBackward()) {
+		c, err := encoder.Encode(position{from})
+		if err != nil {
+			return paginator.Cursor{}, err
+		}
+		result.Before = &c
+	}
+	return
+}
+
+func (f *Fake) decode(c string) (int, error) {
+	var p position
+	if err := cursor.NewDecoder("Index").DecodeStruct(c, &p); err != nil {
+		return 0, paginator.ErrInvalidCursor
+	}
+	return p.Index, nil
+}
+
+func clamp(i, length int) int {
+	if i < 0 {
+		return 0
+	}
+	if i > length {
+		return length
+	}
+	return i
+}
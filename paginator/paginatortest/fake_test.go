@@ -0,0 +1,123 @@
+package paginatortest
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/hashicorp/gorm-cursor-paginator/paginator"
+)
+
+func TestFake(t *testing.T) {
+	suite.Run(t, &fakeSuite{})
+}
+
+type fakeSuite struct {
+	suite.Suite
+}
+
+type record struct {
+	ID int
+}
+
+func (s *fakeSuite) givenRecords(n int) (records []record) {
+	for i := 1; i <= n; i++ {
+		records = append(records, record{ID: i})
+	}
+	return
+}
+
+func (s *fakeSuite) TestPaginate() {
+	records := s.givenRecords(5)
+
+	var p1 []record
+	_, c, err := (&Fake{Items: records, Limit: 2}).Paginate(nil, &p1)
+	s.Nil(err)
+	AssertIDRange(s.T(), p1, 1, 2)
+	AssertForwardOnly(s.T(), c)
+
+	var p2 []record
+	_, c, _ = (&Fake{Items: records, Limit: 2, Cursor: paginator.Cursor{After: c.After}}).Paginate(nil, &p2)
+	AssertIDRange(s.T(), p2, 3, 4)
+	AssertBothDirections(s.T(), c)
+
+	var p3 []record
+	_, c, _ = (&Fake{Items: records, Limit: 2, Cursor: paginator.Cursor{After: c.After}}).Paginate(nil, &p3)
+	AssertIDs(s.T(), p3, 5)
+	AssertBackwardOnly(s.T(), c)
+
+	var p4 []record
+	_, c, _ = (&Fake{Items: records, Limit: 2, Cursor: paginator.Cursor{Before: c.Before}}).Paginate(nil, &p4)
+	AssertIDRange(s.T(), p4, 3, 4)
+	AssertBothDirections(s.T(), c)
+}
+
+func (s *fakeSuite) TestPaginateNoMore() {
+	var records []record
+	_, c, err := (&Fake{Items: s.givenRecords(2), Limit: 2}).Paginate(nil, &records)
+	s.Nil(err)
+	AssertIDRange(s.T(), records, 1, 2)
+	AssertNoMore(s.T(), c)
+}
+
+func (s *fakeSuite) TestPaginateError() {
+	var records []record
+	_, _, err := (&Fake{Items: s.givenRecords(2), Limit: 2, Err: gorm.ErrRecordNotFound}).Paginate(nil, &records)
+	s.Equal(gorm.ErrRecordNotFound, err)
+
+	_, _, err = (&Fake{Items: s.givenRecords(2), Limit: 2}).Paginate(nil, &[]struct{ ID string }{})
+	s.Equal(paginator.ErrInvalidModel, err)
+
+	invalid := "invalid cursor"
+	_, _, err = (&Fake{Items: s.givenRecords(2), Limit: 2, Cursor: paginator.Cursor{After: &invalid}}).Paginate(nil, &records)
+	s.Equal(paginator.ErrInvalidCursor, err)
+}
+
+func (s *fakeSuite) TestAssertInvariants() {
+	for _, n := range []int{0, 1, 3, 7} {
+		records := s.givenRecords(n)
+		s.True(AssertInvariants(s.T(), nil, &[]record{}, n, func(c paginator.Cursor) paginator.Interface {
+			return &Fake{Items: records, Limit: 3, Cursor: c}
+		}), fmt.Sprintf("%d records", n))
+	}
+}
+
+func (s *fakeSuite) TestAssertInvariantsGap() {
+	t := &recorder{}
+	records := s.givenRecords(7)
+	s.False(AssertInvariants(t, nil, &[]record{}, 8, func(c paginator.Cursor) paginator.Interface {
+		return &Fake{Items: records, Limit: 3, Cursor: c}
+	}))
+	s.NotEmpty(t.errors)
+}
+
+func (s *fakeSuite) TestAssertInvariantsError() {
+	t := &recorder{}
+	s.False(AssertInvariants(t, nil, &[]record{}, 1, func(c paginator.Cursor) paginator.Interface {
+		return &Fake{Err: errors.New("database is gone")}
+	}))
+	s.NotEmpty(t.errors)
+}
+
+func (s *fakeSuite) TestAssertInvariantsLoop() {
+	t := &recorder{}
+	records := s.givenRecords(7)
+	s.False(AssertInvariants(t, nil, &[]record{}, 7, func(c paginator.Cursor) paginator.Interface {
+		// cursor is never moving
+		return &Fake{Items: records, Limit: 3}
+	}))
+	s.NotEmpty(t.errors)
+}
+
+/* util */
+
+type recorder struct {
+	errors []string
+}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
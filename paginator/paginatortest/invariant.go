@@ -0,0 +1,137 @@
+package paginatortest
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/hashicorp/gorm-cursor-paginator/paginator"
+)
+
+// NewPaginator creates paginator starting from given cursor
+type NewPaginator func(c paginator.Cursor) paginator.Interface
+
+// AssertInvariants walks all pages forward and then backward with paginators created by newPaginator,
+// and asserts both walks visit exactly total records with neither duplicates nor gaps in a stable order.
+// dest must be a pointer to slice of records, it is only used for determining record type.
+func AssertInvariants(t assert.TestingT, db *gorm.DB, dest interface{}, total int, newPaginator NewPaginator) bool {
+	rt := reflect.TypeOf(dest)
+	if rt.Kind() != reflect.Ptr || rt.Elem().Kind() != reflect.Slice {
+		return assert.Fail(t, "AssertInvariants: dest should be a pointer to slice")
+	}
+	w := walker{
+		t:            t,
+		db:           db,
+		sliceType:    rt.Elem(),
+		maxPages:     total + 1,
+		newPaginator: newPaginator,
+	}
+
+	forwardPages, ok := w.walk(paginator.Cursor{})
+	if !ok {
+		return false
+	}
+	if !assert.Nil(t, forwardPages[0].cursor.Before, "first page should have no before cursor") {
+		return false
+	}
+	forward := concat(forwardPages)
+	if !assertNoDuplicates(t, "forward", forward) ||
+		!assert.Len(t, forward, total, "forward walk should visit all records") {
+		return false
+	}
+	lastPage := forwardPages[len(forwardPages)-1]
+	if lastPage.cursor.Before == nil {
+		return assert.Len(t, forwardPages, 1, "only single page has no before cursor")
+	}
+
+	backwardPages, ok := w.walk(paginator.Cursor{Before: lastPage.cursor.Before})
+	if !ok {
+		return false
+	}
+	if !assert.NotNil(t, backwardPages[0].cursor.After, "page walked backward should have after cursor") {
+		return false
+	}
+	// backward walk visits pages from the end, restore them to forward order
+	pages := []page{lastPage}
+	for _, p := range backwardPages {
+		pages = append([]page{p}, pages...)
+	}
+	backward := concat(pages)
+	return assertNoDuplicates(t, "backward", backward) &&
+		assert.Equal(t, forward, backward, "forward and backward walks should visit records in the same order")
+}
+
+/* private */
+
+type page struct {
+	records []string
+	cursor  paginator.Cursor
+}
+
+type walker struct {
+	t            assert.TestingT
+	db           *gorm.DB
+	sliceType    reflect.Type
+	maxPages     int
+	newPaginator NewPaginator
+}
+
+// walk paginates from c toward the direction c specifies until there is no more page
+func (w *walker) walk(c paginator.Cursor) (pages []page, ok bool) {
+	for {
+		if len(pages) > w.maxPages {
+			return nil, assert.Fail(w.t, "paginator does not stop, pages are likely looping")
+		}
+		dest := reflect.New(w.sliceType)
+		result, next, err := w.newPaginator(c).Paginate(w.db, dest.Interface())
+		if !assert.Nil(w.t, err) {
+			return nil, false
+		}
+		if result != nil && !assert.Nil(w.t, result.Error) {
+			return nil, false
+		}
+		pages = append(pages, page{
+			records: identities(dest.Elem()),
+			cursor:  next,
+		})
+		if c.Before != nil {
+			if next.Before == nil {
+				return pages, true
+			}
+			c = paginator.Cursor{Before: next.Before}
+		} else {
+			if next.After == nil {
+				return pages, true
+			}
+			c = paginator.Cursor{After: next.After}
+		}
+	}
+}
+
+func concat(pages []page) (records []string) {
+	for _, p := range pages {
+		records = append(records, p.records...)
+	}
+	return
+}
+
+func identities(elems reflect.Value) []string {
+	result := make([]string, elems.Len())
+	for i := 0; i < elems.Len(); i++ {
+		result[i] = fmt.Sprintf("%+v", reflect.Indirect(elems.Index(i)).Interface())
+	}
+	return result
+}
+
+func assertNoDuplicates(t assert.TestingT, direction string, records []string) bool {
+	seen := make(map[string]bool, len(records))
+	for _, r := range records {
+		if seen[r] {
+			return assert.Fail(t, fmt.Sprintf("%s walk visits record more than once: %s", direction, r))
+		}
+		seen[r] = true
+	}
+	return true
+}
@@ -0,0 +1,272 @@
+package paginator
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/hashicorp/gorm-cursor-paginator/internal/util"
+)
+
+// Range is a range of rows in paging order, from right after After (exclusive) until Until (inclusive),
+// nil After starts from the very first row and nil Until ends at the very last row.
+// Range can be walked with WithAfter(*After) and WithUntil(*Until).
+type Range struct {
+	After *string
+	Until *string
+}
+
+// Partition splits rows into at most n non-overlapping ranges covering all rows exactly once, so that
+// ranges can be paginated concurrently. Boundaries are interpolated between min and max of the first
+// key when it is numeric, otherwise they are sampled by row offsets. Ranges are balanced by row count
+// only when the first key is evenly distributed or sampled by offsets. n should be greater than 0.
+//
+// Only rows the paginator would walk are partitioned, i.e., rows after its after cursor, within its until
+// cursor and snapshot bound. Aggregate rules and before cursor are not supported.
+func (p *Paginator) Partition(db *gorm.DB, dest interface{}, n int) (ranges []Range, err error) {
+	if n < 1 {
+		return nil, ErrInvalidPartitions
+	}
+	if p.hasAggregateRule() || p.isBackward() {
+		return nil, ErrUnsupportedPartition
+	}
+	fields, err := p.prepare(db, dest)
+	if err != nil {
+		return
+	}
+	var boundaries [][]interface{}
+	if p.isNumericKey(dest) {
+		boundaries, err = p.interpolateBoundaries(db, dest, fields, n)
+	} else {
+		boundaries, err = p.sampleBoundaries(db, dest, fields, n)
+	}
+	if err != nil {
+		return
+	}
+	codec := p.newCursorCodec(dest)
+	after := p.cursor.After
+	for _, values := range boundaries {
+		c, err := codec.encode(values)
+		if err != nil {
+			return nil, err
+		}
+		// key ties or sparse keys could result in the same boundary
+		if after != nil && *after == c {
+			continue
+		}
+		ranges = append(ranges, Range{After: after, Until: &c})
+		after = &c
+	}
+	// the last boundary could be until cursor itself
+	if p.until != nil && after != nil && *after == *p.until {
+		return ranges, nil
+	}
+	return append(ranges, Range{After: after, Until: p.until}), nil
+}
+
+/* private */
+
+func (p *Paginator) isNumericKey(dest interface{}) bool {
+	// ranks or hashes are not spread between values of key
+	if len(p.rules[0].Values) > 0 || p.rules[0].Shuffle {
+		return false
+	}
+	f, _ := util.ReflectFieldByPath(dest, p.rules[0].Key)
+	return isNumberKind(util.ReflectType(f.Type).Kind())
+}
+
+// interpolateBoundaries takes the last row in paging order not beyond each value interpolated
+// between min and max of the first key as boundary
+func (p *Paginator) interpolateBoundaries(
+	db *gorm.DB,
+	dest interface{},
+	fields []interface{},
+	n int,
+) (boundaries [][]interface{}, err error) {
+	first := p.rules[0]
+	f, _ := util.ReflectFieldByPath(dest, first.Key)
+	// integers are interpolated in integer arithmetic, as float64 cannot hold every int64 or uint64
+	var min, max interface{}
+	switch kind := util.ReflectType(f.Type).Kind(); {
+	case isIntKind(kind):
+		min, max = new(*int64), new(*int64)
+	case isUintKind(kind):
+		min, max = new(*uint64), new(*uint64)
+	default:
+		min, max = new(*float64), new(*float64)
+	}
+	if err = p.newBoundaryStmt(
+		db,
+		dest,
+		fields,
+		fmt.Sprintf("MIN(%[1]s), MAX(%[1]s)", first.SQLRepr),
+		nil, "", nil, "", nil, 0,
+	).Row().Scan(min, max); err != nil || reflect.ValueOf(min).Elem().IsNil() {
+		return
+	}
+	lo, hi := reflect.ValueOf(min).Elem().Elem(), reflect.ValueOf(max).Elem().Elem()
+	cond := "<="
+	if first.Order == DESC {
+		cond = ">="
+	}
+	for i := 1; i < n; i++ {
+		values, ok, err := p.queryBoundary(
+			db,
+			dest,
+			fields,
+			fmt.Sprintf("%s %s ?", first.SQLRepr, cond),
+			[]interface{}{interpolate(lo, hi, first.Order, i, n)},
+			true,
+			0,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			boundaries = append(boundaries, values)
+		}
+	}
+	return
+}
+
+// interpolate returns value at i/n of the way from min to max in order,
+// integers are rounded towards the start of order
+func interpolate(min, max reflect.Value, order Order, i, n int) interface{} {
+	if min.Kind() == reflect.Float64 {
+		d := (max.Float() - min.Float()) * float64(i) / float64(n)
+		if order == ASC {
+			return min.Float() + d
+		}
+		return max.Float() - d
+	}
+	lo, hi := bigInt(min), bigInt(max)
+	d := new(big.Int).Sub(hi, lo)
+	d.Mul(d, big.NewInt(int64(i))).Quo(d, big.NewInt(int64(n)))
+	v := lo.Add(lo, d)
+	if order == DESC {
+		v = hi.Sub(hi, d)
+	}
+	if min.Kind() == reflect.Uint64 {
+		return v.Uint64()
+	}
+	return v.Int64()
+}
+
+func bigInt(v reflect.Value) *big.Int {
+	if v.Kind() == reflect.Uint64 {
+		return new(big.Int).SetUint64(v.Uint())
+	}
+	return big.NewInt(v.Int())
+}
+
+// sampleBoundaries takes rows at every 1/n of rows in paging order as boundaries
+func (p *Paginator) sampleBoundaries(
+	db *gorm.DB,
+	dest interface{},
+	fields []interface{},
+	n int,
+) (boundaries [][]interface{}, err error) {
+	var count int
+	if err = p.newBoundaryStmt(db, dest, fields, "COUNT(*)", nil, "", nil, "", nil, 0).Row().Scan(&count); err != nil {
+		return
+	}
+	for i := 1; i < n; i++ {
+		offset := count*i/n - 1
+		if offset < 0 {
+			continue
+		}
+		values, ok, err := p.queryBoundary(db, dest, fields, "", nil, false, offset)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			boundaries = append(boundaries, values)
+		}
+	}
+	return
+}
+
+// queryBoundary queries keys of the row at offset in paging order, or in reversed paging order
+func (p *Paginator) queryBoundary(
+	db *gorm.DB,
+	dest interface{},
+	fields []interface{},
+	cond string,
+	args []interface{},
+	reversed bool,
+	offset int,
+) (values []interface{}, ok bool, err error) {
+	columns, columnArgs := p.buildKeysSQL()
+	orders := make([]string, len(p.rules))
+	var orderArgs []interface{}
+	for i, rule := range p.rules {
+		order := rule.Order
+		if reversed {
+			order = order.flip()
+		}
+		orders[i] = fmt.Sprintf("%s %s", rule.orderSQLRepr(), order)
+		orderArgs = append(orderArgs, rule.orderSQLArgs()...)
+	}
+	rows, err := p.newBoundaryStmt(
+		db,
+		dest,
+		fields,
+		columns,
+		columnArgs,
+		cond,
+		args,
+		strings.Join(orders, ", "),
+		orderArgs,
+		offset,
+	).Rows()
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+	if rows.Next() {
+		values, err = p.scanCursorValues(rows, dest)
+		ok = err == nil
+		return
+	}
+	err = rows.Err()
+	return
+}
+
+// newBoundaryStmt selects columns of rows matching cond within cursors and snapshot bound of paginator,
+// only the row at offset is selected when order is given
+func (p *Paginator) newBoundaryStmt(
+	db *gorm.DB,
+	dest interface{},
+	fields []interface{},
+	columns string,
+	columnArgs []interface{},
+	cond string,
+	condArgs []interface{},
+	order string,
+	orderArgs []interface{},
+	offset int,
+) *gorm.DB {
+	if p.subquery {
+		var conds []string
+		if cond != "" {
+			conds = append(conds, cond)
+		}
+		query, args := p.buildWrappedQuery(db, fields, columns, columnArgs, conds, condArgs)
+		if order != "" {
+			query += fmt.Sprintf(" ORDER BY %s LIMIT 1 OFFSET %d", order, offset)
+			args = append(args, orderArgs...)
+		}
+		return newWrappingStmt(db).Raw(query, args...)
+	}
+	stmt := p.appendPagingFilters(db.Model(dest).Select(columns, columnArgs...), fields)
+	if cond != "" {
+		stmt = stmt.Where(cond, condArgs...)
+	}
+	if order != "" {
+		stmt = stmt.Order(gorm.Expr(order, orderArgs...)).Limit(1).Offset(offset)
+	}
+	return stmt
+}
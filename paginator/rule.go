@@ -1,22 +1,173 @@
 package paginator
 
-import "github.com/hashicorp/gorm-cursor-paginator/internal/util"
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/gorm-cursor-paginator/internal/util"
+)
 
 // Rule for paginator
 type Rule struct {
 	Key     string
 	Order   Order
 	SQLRepr string
+	// Collation is applied to both ordering and cursor comparison of SQLRepr as is,
+	// e.g., `"und-x-icu"` for postgres, "NOCASE" for sqlite, "utf8mb4_unicode_ci" for mysql
+	Collation string
+	// Values orders key by position of its value in the list instead of the value itself,
+	// e.g., []interface{}{"urgent", "open", "pending", "closed"}, values not in the list rank last.
+	// Cursor stores the rank. Numbers should be exact for integer keys, e.g., 2.0 but not 2.5.
+	Values []interface{}
+	// Shuffle orders key by a hash of its value and seed of paginator, so that rows are shuffled
+	// stably across pages. Key should be unique non-nullable integer or string, e.g., primary key.
+	Shuffle bool
+	// Aggregate marks SQLRepr as an aggregate expression (e.g., "COUNT(orders.id)"),
+	// cursor, snapshot and until predicates are put into HAVING instead of WHERE when any rule is aggregate
+	Aggregate bool
+
+	// seed and dialect of shuffle rules are set up by paginator
+	seed    string
+	dialect string
+	// tieBreaker orders rows of equal hashes of the preceding shuffle rule by raw key
+	tieBreaker bool
 }
 
 func (r *Rule) validate(dest interface{}) (err error) {
-	if _, ok := util.ReflectFieldByPath(dest, r.Key); !ok {
-		return ErrInvalidModel
+	f, ok := util.ReflectFieldByPath(dest, r.Key)
+	if !ok {
+		return &KeyError{Key: r.Key, Err: ErrInvalidModel}
+	}
+	for _, v := range r.Values {
+		if !isRankValue(reflect.ValueOf(v), util.ReflectType(f.Type)) {
+			return &KeyError{Key: r.Key, Type: f.Type, Err: ErrInvalidRuleValues}
+		}
+	}
+	if r.Shuffle {
+		// NULL has no hash, so nullable keys are not shuffled
+		nullable := f.Type.Kind() == reflect.Ptr
+		if k := util.ReflectType(f.Type).Kind(); nullable || (k != reflect.String && !isIntegerKind(k)) {
+			return &KeyError{Key: r.Key, Type: f.Type, Err: ErrInvalidShuffleRule}
+		}
+	}
+	// aggregate expression cannot be derived from key
+	if r.Aggregate && r.SQLRepr == "" {
+		return &KeyError{Key: r.Key, Type: f.Type, Err: ErrInvalidAggregateRule}
 	}
 	if r.Order != "" {
 		if err = r.Order.validate(); err != nil {
-			return
+			return &KeyError{Key: r.Key, Type: f.Type, Err: err}
 		}
 	}
 	return nil
 }
+
+// orderSQLRepr returns SQL expression of rule used in both ordering and comparison,
+// so that they always agree, args of placeholders in it are returned by orderSQLArgs
+func (r *Rule) orderSQLRepr() string {
+	if r.Shuffle {
+		return r.shuffleSQLRepr()
+	}
+	sqlRepr := r.SQLRepr
+	if r.Collation != "" {
+		sqlRepr = fmt.Sprintf("%s COLLATE %s", sqlRepr, r.Collation)
+	}
+	if len(r.Values) == 0 {
+		return sqlRepr
+	}
+	// for example:
+	// CASE tickets.status WHEN ? THEN 0 WHEN ? THEN 1 ELSE 2 END
+	var b strings.Builder
+	fmt.Fprintf(&b, "CASE %s", sqlRepr)
+	for i := range r.Values {
+		fmt.Fprintf(&b, " WHEN ? THEN %d", i)
+	}
+	fmt.Fprintf(&b, " ELSE %d END", len(r.Values))
+	return b.String()
+}
+
+// orderSQLArgs returns args of placeholders in orderSQLRepr
+func (r *Rule) orderSQLArgs() []interface{} {
+	if r.Shuffle {
+		return r.shuffleSQLArgs()
+	}
+	return r.Values
+}
+
+// cursorType returns type of value stored in cursor for rule
+func (r *Rule) cursorType(dest interface{}) reflect.Type {
+	if len(r.Values) > 0 {
+		return reflect.TypeOf(0)
+	}
+	if r.Shuffle {
+		return reflect.TypeOf("")
+	}
+	// dest is already validated at validation phase
+	f, _ := util.ReflectFieldByPath(dest, r.Key)
+	return f.Type
+}
+
+// rank returns position of value in values, or number of values when it is not listed
+func (r *Rule) rank(value reflect.Value) int {
+	value = reflect.Indirect(value)
+	if !value.IsValid() {
+		return len(r.Values)
+	}
+	for i, v := range r.Values {
+		if reflect.ValueOf(v).Convert(value.Type()).Interface() == value.Interface() {
+			return i
+		}
+	}
+	return len(r.Values)
+}
+
+func isRankValue(v reflect.Value, t reflect.Type) bool {
+	switch {
+	case !v.IsValid():
+		return false
+	case v.Kind() == reflect.String:
+		return t.Kind() == reflect.String
+	case isNumberKind(v.Kind()) && isIntegerKind(t.Kind()):
+		return isExactInteger(v, t)
+	case isNumberKind(v.Kind()):
+		return isNumberKind(t.Kind())
+	}
+	return false
+}
+
+// isExactInteger reports whether number v keeps its value converted to integer type t,
+// so that rank never matches a key truncated or wrapped from v
+func isExactInteger(v reflect.Value, t reflect.Type) bool {
+	switch {
+	case isIntKind(v.Kind()) && isUintKind(t.Kind()):
+		return v.Int() >= 0 && v.Convert(t).Uint() == uint64(v.Int())
+	case isUintKind(v.Kind()) && isIntKind(t.Kind()):
+		return v.Uint() <= math.MaxInt64 && v.Convert(t).Int() == int64(v.Uint())
+	case isIntKind(v.Kind()):
+		return v.Convert(t).Int() == v.Int()
+	case isUintKind(v.Kind()):
+		return v.Convert(t).Uint() == v.Uint()
+	}
+	// floats beyond range of t convert to arbitrary integers, so range is checked first
+	f := v.Float()
+	if isUintKind(t.Kind()) {
+		return f >= 0 && f < math.Pow(2, float64(t.Bits())) && float64(v.Convert(t).Uint()) == f
+	}
+	limit := math.Pow(2, float64(t.Bits()-1))
+	return f >= -limit && f < limit && float64(v.Convert(t).Int()) == f
+}
+
+func isNumberKind(k reflect.Kind) bool {
+	return isIntegerKind(k) || k == reflect.Float32 || k == reflect.Float64
+}
+
+func isIntegerKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,159 @@
+package paginator
+
+import (
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"reflect"
+	"regexp"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/hashicorp/gorm-cursor-paginator/internal/util"
+)
+
+// sqlite has no md5, shuffle hash is mixed by arithmetic on integer keys within int64
+const (
+	shuffleModulus    = 2147483647
+	shuffleMultiplier = 48271
+)
+
+// seedRegexp matches seeds generated by newSeed
+var seedRegexp = regexp.MustCompile(`^[0-9a-f]{16}$`)
+
+var errInvalidSeed = errors.New("seed is not generated by paginator")
+
+/* private */
+
+func (p *Paginator) hasShuffleRule() bool {
+	for _, rule := range p.rules {
+		if rule.Shuffle {
+			return true
+		}
+	}
+	return false
+}
+
+// setupShuffle sets up seed and dialect of shuffle rules, seed is generated when there is none
+func (p *Paginator) setupShuffle(db *gorm.DB, dest interface{}) error {
+	return p.setupShuffleDialect(db.Dialect().GetName(), dest)
+}
+
+// setupShuffleDialect sets up seed and dialect of shuffle rules like setupShuffle does for statements of dialect
+func (p *Paginator) setupShuffleDialect(dialect string, dest interface{}) error {
+	if !p.hasShuffleRule() {
+		return nil
+	}
+	if p.seed == "" {
+		seed, err := newSeed()
+		if err != nil {
+			return err
+		}
+		p.seed = seed
+	}
+	for i, rule := range p.rules {
+		if !rule.Shuffle {
+			continue
+		}
+		switch dialect {
+		case "postgres", "mysql":
+		case "sqlite3":
+			f, _ := util.ReflectFieldByPath(dest, rule.Key)
+			if !isIntegerKind(util.ReflectType(f.Type).Kind()) {
+				return ErrUnsupportedDialect
+			}
+		default:
+			return ErrUnsupportedDialect
+		}
+		p.rules[i].seed = p.seed
+		p.rules[i].dialect = dialect
+	}
+	return nil
+}
+
+// newTieBreaker returns rule ordering rows of equal hashes of shuffle rule by raw key, so that keyset
+// paging never skips rows of colliding hashes
+func (r *Rule) newTieBreaker() Rule {
+	return Rule{
+		Key:        r.Key,
+		Order:      r.Order,
+		SQLRepr:    r.SQLRepr,
+		tieBreaker: true,
+	}
+}
+
+// decodeSeed sets seed of shuffle rules from value at position of cursor, seed is bound to statement
+// but still only seeds in format of generated seeds are accepted
+func (p *Paginator) decodeSeed(value interface{}, position int) error {
+	seed := value.(string)
+	if !seedRegexp.MatchString(seed) {
+		return &CursorError{Type: reflect.TypeOf(seed), Position: position, Cause: errInvalidSeed}
+	}
+	p.seed = seed
+	return nil
+}
+
+func newSeed() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// formatSeed hashes configured seed into format of generated seeds
+func formatSeed(seed string) string {
+	sum := sha256.Sum256([]byte(seed))
+	return hex.EncodeToString(sum[:8])
+}
+
+// shuffleSQLRepr returns SQL hashing key with seed, it must agree with shuffleHash,
+// seed is bound by shuffleSQLArgs
+func (r *Rule) shuffleSQLRepr() string {
+	switch r.dialect {
+	case "postgres":
+		return fmt.Sprintf("md5(CAST(%s AS TEXT) || CAST(? AS TEXT))", r.SQLRepr)
+	case "mysql":
+		return fmt.Sprintf("MD5(CONCAT(%s, ?))", r.SQLRepr)
+	}
+	// for example:
+	// h = (id % m * a + s) % m, then (h * h + s) % m
+	h := fmt.Sprintf("((%s %% %d) * %d + ?) %% %d", r.SQLRepr, shuffleModulus, shuffleMultiplier, shuffleModulus)
+	return fmt.Sprintf("CAST(((%[1]s) * (%[1]s) + ?) %% %[2]d AS TEXT)", h, shuffleModulus)
+}
+
+// shuffleSQLArgs returns args of placeholders in shuffleSQLRepr
+func (r *Rule) shuffleSQLArgs() []interface{} {
+	switch r.dialect {
+	case "postgres", "mysql":
+		return []interface{}{r.seed}
+	}
+	return []interface{}{r.intSeed(), r.intSeed(), r.intSeed()}
+}
+
+// shuffleHash returns hash of key value with seed, it must agree with shuffleSQLRepr
+func (r *Rule) shuffleHash(value reflect.Value) string {
+	value = reflect.Indirect(value)
+	if r.dialect == "sqlite3" {
+		var x int64
+		if k := value.Kind(); k >= reflect.Uint && k <= reflect.Uint64 {
+			x = int64(value.Uint() % shuffleModulus)
+		} else {
+			x = value.Int() % shuffleModulus
+		}
+		h := (x*shuffleMultiplier + r.intSeed()) % shuffleModulus
+		return fmt.Sprint((h*h + r.intSeed()) % shuffleModulus)
+	}
+	sum := md5.Sum([]byte(fmt.Sprint(value.Interface()) + r.seed))
+	return hex.EncodeToString(sum[:])
+}
+
+func (r *Rule) intSeed() int64 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(r.seed))
+	return int64(h.Sum32() % shuffleModulus)
+}
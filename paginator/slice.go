@@ -0,0 +1,135 @@
+package paginator
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/hashicorp/gorm-cursor-paginator/internal/util"
+)
+
+// PaginateSlice paginates items in memory into dest by the same rules and cursors as Paginate. Keys are
+// compared as the slice dialect orders them (postgres unless set by SetSliceDialect), including placement
+// of NULL and hashes of shuffle rules, so that cursors are interchangeable with Paginate on databases of
+// that dialect. Rules the dialect orders differently from Go fail with ErrInvalidSliceRule, e.g., strings
+// under collations not ordering by bytes. Items should be a slice of the same type as dest points to,
+// it is left untouched.
+func (p *Paginator) PaginateSlice(items interface{}, dest interface{}) (c Cursor, err error) {
+	src := reflect.ValueOf(items)
+	if src.Kind() != reflect.Slice ||
+		reflect.TypeOf(dest).Kind() != reflect.Ptr ||
+		reflect.TypeOf(dest).Elem() != src.Type() {
+		return Cursor{}, ErrInvalidModel
+	}
+	if err = p.validate(dest); err != nil {
+		return
+	}
+	dialect := p.getSliceDialect()
+	switch dialect {
+	case "postgres", "mysql", "sqlite3":
+	default:
+		return Cursor{}, ErrUnsupportedDialect
+	}
+	for _, rule := range p.rules {
+		if mergeClass(rule, dest, dialect) == "" {
+			return Cursor{}, ErrInvalidSliceRule
+		}
+	}
+	nullsFirst := sortsNullsFirst(dialect)
+	fields, err := p.prepareSlice(src, dest, dialect)
+	if err != nil {
+		return
+	}
+	// rows are sorted in paging order, page is taken from the end of rows when paging backward
+	var rows []reflect.Value
+	for i := 0; i < src.Len(); i++ {
+		if p.inSlicePage(src.Index(i), fields, nullsFirst) {
+			rows = append(rows, src.Index(i))
+		}
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		return p.compareKeys(p.getCursorValues(rows[i]), p.getCursorValues(rows[j]), nullsFirst) < 0
+	})
+	hasMore := len(rows) > p.limit
+	if hasMore && p.isBackward() {
+		rows = rows[len(rows)-p.limit:]
+	} else if hasMore {
+		rows = rows[:p.limit]
+	}
+	elems := reflect.MakeSlice(src.Type(), 0, len(rows))
+	for _, row := range rows {
+		elems = reflect.Append(elems, row)
+	}
+	reflect.ValueOf(dest).Elem().Set(elems)
+	if len(rows) > 0 {
+		c, err = p.encodeCursor(elems, hasMore)
+	}
+	return
+}
+
+/* private */
+
+func (p *Paginator) getSliceDialect() string {
+	if p.sliceDialect == "" {
+		return "postgres"
+	}
+	return p.sliceDialect
+}
+
+// prepareSlice sets up paginator for items like prepare does for statement, returns decoded fields of cursor
+func (p *Paginator) prepareSlice(items reflect.Value, dest interface{}, dialect string) (fields []interface{}, err error) {
+	for i := range p.rules {
+		if p.rules[i].Order == "" {
+			p.rules[i].Order = p.order
+		}
+	}
+	if fields, err = p.decodeCursor(dest); err != nil {
+		return
+	}
+	if p.until != nil {
+		if p.untilFields, err = p.decodeUntil(dest); err != nil {
+			return
+		}
+	}
+	if err = p.setupShuffleDialect(dialect, dest); err != nil {
+		return
+	}
+	// bound is the max value of snapshot key, nil when there is none
+	if p.snapshot != nil && fields == nil {
+		bound := reflect.New(p.getSnapshotBoundType(dest)).Elem()
+		for i := 0; i < items.Len(); i++ {
+			value := util.ReflectValueByPath(items.Index(i), p.snapshot.Key)
+			if !indirectValue(value).IsValid() {
+				continue
+			}
+			if !indirectValue(bound).IsValid() || compareValues(value, bound, false) > 0 {
+				bound.Set(reflect.New(bound.Type().Elem()))
+				bound.Elem().Set(indirectValue(value))
+			}
+		}
+		p.bound = bound.Interface()
+	}
+	return
+}
+
+// inSlicePage reports whether item is after cursor, within until cursor and snapshot bound
+func (p *Paginator) inSlicePage(item reflect.Value, fields []interface{}, nullsFirst bool) bool {
+	keys := p.getCursorValues(item)[:len(p.rules)]
+	if p.isForward() && p.compareKeys(keys, fields, nullsFirst) <= 0 {
+		return false
+	}
+	if p.isBackward() && p.compareKeys(keys, fields, nullsFirst) >= 0 {
+		return false
+	}
+	if p.until != nil && p.compareKeys(keys, p.untilFields, nullsFirst) > 0 {
+		return false
+	}
+	// statements are not bounded when snapshot has no bound, e.g., all values of snapshot key are NULL,
+	// otherwise NULL is beyond bound as it compares to nothing in database
+	if bound := reflect.ValueOf(p.bound); p.snapshot != nil && indirectValue(bound).IsValid() {
+		value := util.ReflectValueByPath(item, p.snapshot.Key)
+		if !indirectValue(value).IsValid() || compareValues(value, bound, false) > 0 {
+			return false
+		}
+	}
+	return true
+}
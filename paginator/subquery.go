@@ -0,0 +1,65 @@
+package paginator
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/jinzhu/gorm"
+)
+
+// subqueryAlias is the alias of derived table wrapping statement in subquery mode
+const subqueryAlias = "page_src"
+
+/* private */
+
+// appendWrappedPagingQuery wraps statement as a derived table and pages over it, e.g.,
+// SELECT <columns> FROM (<statement>) AS page_src WHERE ... ORDER BY ... LIMIT ...
+func (p *Paginator) appendWrappedPagingQuery(
+	db *gorm.DB, fields []interface{}, columns string, columnArgs []interface{},
+) *gorm.DB {
+	query, args := p.buildWrappedQuery(db, fields, columns, columnArgs, nil, nil)
+	order, orderArgs := p.buildOrderSQL()
+	query += fmt.Sprintf(" ORDER BY %s LIMIT %d", order, p.limit+1)
+	return newWrappingStmt(db).Raw(query, append(args, orderArgs...)...)
+}
+
+// buildWrappedQuery builds query selecting columns of statement wrapped as a derived table,
+// filtered by conds and paging conditions, args start with args of columns and the statement
+func (p *Paginator) buildWrappedQuery(
+	db *gorm.DB,
+	fields []interface{},
+	columns string,
+	columnArgs []interface{},
+	conds []string,
+	condArgs []interface{},
+) (string, []interface{}) {
+	args := append([]interface{}{}, columnArgs...)
+	args = append(args, db.QueryExpr())
+	args = append(args, condArgs...)
+	if len(fields) > 0 {
+		conds = append(conds, fmt.Sprintf("(%s)", p.buildCursorSQLQuery()))
+		args = append(args, p.buildCursorSQLQueryArgs(fields)...)
+	}
+	if p.snapshot != nil {
+		if bound := reflect.ValueOf(p.bound); !bound.IsNil() {
+			conds = append(conds, fmt.Sprintf("%s <= ?", p.snapshot.SQLRepr))
+			args = append(args, bound.Elem().Interface())
+		}
+	}
+	if p.untilFields != nil {
+		conds = append(conds, fmt.Sprintf("(%s)", p.buildUntilSQLQuery()))
+		args = append(args, p.buildUntilSQLQueryArgs(p.untilFields)...)
+	}
+	query := fmt.Sprintf("SELECT %s FROM (?) AS %s", columns, subqueryAlias)
+	if len(conds) > 0 {
+		query += " WHERE " + strings.Join(conds, " AND ")
+	}
+	return query, args
+}
+
+// newWrappingStmt returns statement for wrapping db, soft delete condition of dest
+// would break raw SQL, and db being wrapped keeps its own conditions anyway
+func newWrappingStmt(db *gorm.DB) *gorm.DB {
+	return db.New().Unscoped()
+}
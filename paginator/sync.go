@@ -0,0 +1,105 @@
+package paginator
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/hashicorp/gorm-cursor-paginator/internal/util"
+)
+
+// Sync pages through rows changed after token in ascending change order, rules should lead
+// with change key, e.g., keys "UpdatedAt", "ID". Empty token starts from the very first change.
+//
+// Returned token resumes right after the last row of page, or equals given token when
+// there is no change, so it can always be stored by clients for next sync.
+//
+// Rows soft deleted by GORM (model having "DeletedAt" field) are included as tombstones,
+// their change time is taken from DeletedAt, since soft delete does not touch change key.
+func (p *Paginator) Sync(db *gorm.DB, dest interface{}, token string) (result *gorm.DB, next string, hasMore bool, err error) {
+	sp := p.newSyncPaginator(token)
+	if err = sp.validate(dest); err != nil {
+		return
+	}
+	deletedAt, tombstone := db.NewScope(dest).FieldByName("DeletedAt")
+	if tombstone {
+		db = db.Unscoped()
+	}
+	// change time of tombstone is taken from DeletedAt only when change key is a time without custom SQL
+	coalesce := tombstone && sp.rules[0].SQLRepr == "" && sp.isChangeTime(dest, deletedAt.Struct.Type)
+	if coalesce {
+		var sqlTable string
+		table := db.NewScope(dest).TableName()
+		if sp.subquery {
+			table = subqueryAlias
+		}
+		sp.rules[0].SQLRepr = fmt.Sprintf(
+			"COALESCE(%s.%s, %s)",
+			table,
+			deletedAt.DBName,
+			sp.buildSQLRepr(db, dest, sp.rules[0].Key, &sqlTable),
+		)
+	}
+	fields, err := sp.prepare(db, dest)
+	if err != nil {
+		return
+	}
+	if result = sp.appendPagingQuery(db, fields).Find(dest); result.Error != nil {
+		return
+	}
+	next = token
+	elems := reflect.ValueOf(dest).Elem()
+	if elems.Kind() != reflect.Slice || elems.Len() == 0 {
+		return
+	}
+	if hasMore = elems.Len() > sp.limit; hasMore {
+		elems.Set(elems.Slice(0, elems.Len()-1))
+	}
+	last := elems.Index(elems.Len() - 1)
+	values := sp.getCursorValues(last)
+	if coalesce {
+		values[0] = sp.getChangeValue(last, values[0])
+	}
+	next, err = sp.newCursorCodec(dest).encode(values)
+	return
+}
+
+/* private */
+
+func (p *Paginator) newSyncPaginator(token string) *Paginator {
+	sp := &Paginator{
+		rules:    make([]Rule, len(p.rules)),
+		limit:    p.limit,
+		order:    ASC,
+		subquery: p.subquery,
+	}
+	for i, rule := range p.rules {
+		rule.Order = ASC
+		sp.rules[i] = rule
+	}
+	if token != "" {
+		sp.SetAfterCursor(token)
+	}
+	return sp
+}
+
+func (p *Paginator) isChangeTime(dest interface{}, deletedAtType reflect.Type) bool {
+	f, _ := util.ReflectFieldByPath(dest, p.rules[0].Key)
+	return util.ReflectType(deletedAtType) == util.ReflectType(f.Type)
+}
+
+// getChangeValue returns DeletedAt of tombstone in type of change key, or change value as is
+func (p *Paginator) getChangeValue(elem reflect.Value, change interface{}) interface{} {
+	deletedAt := util.ReflectValueByPath(elem, "DeletedAt")
+	if deletedAt.IsZero() {
+		return change
+	}
+	deletedAt = reflect.Indirect(deletedAt)
+	if reflect.TypeOf(change).Kind() != reflect.Ptr {
+		return deletedAt.Interface()
+	}
+	v := reflect.New(deletedAt.Type())
+	v.Elem().Set(deletedAt)
+	return v.Interface()
+}
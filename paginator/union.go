@@ -0,0 +1,55 @@
+package paginator
+
+import (
+	"reflect"
+
+	"github.com/jinzhu/gorm"
+)
+
+// Source is a statement of union feed
+type Source struct {
+	// Name tags items paginated from source
+	Name string
+	DB   *gorm.DB
+	// Model is a pointer to slice of source model, e.g., &[]Comment{}
+	Model interface{}
+	// Rules are taken instead of rules of paginator when keys are named differently on model,
+	// they should agree with rules of other sources in number and order
+	Rules []Rule
+}
+
+// Item is a row of union feed tagged by name of its source
+type Item struct {
+	Source string
+	Value  interface{}
+}
+
+// PaginateUnion paginates sources of different models into a single page of tagged items in one
+// global order of rules. Cursor holds position of every source, so that each source resumes
+// independently. Sources should be given in the same order on every page.
+func (p *Paginator) PaginateUnion(sources []Source) (items []Item, c Cursor, err error) {
+	ms := make([]*mergeSource, len(sources))
+	for i, source := range sources {
+		t := reflect.TypeOf(source.Model)
+		if t == nil || t.Kind() != reflect.Ptr || t.Elem().Kind() != reflect.Slice {
+			return nil, Cursor{}, ErrInvalidModel
+		}
+		ms[i] = &mergeSource{
+			db:    source.DB,
+			dest:  reflect.New(t.Elem()).Interface(),
+			rules: source.Rules,
+		}
+	}
+	picks, c, err := p.merge(ms)
+	if err != nil {
+		return
+	}
+	items = make([]Item, len(picks))
+	for i, pick := range picks {
+		items[i] = Item{
+			Source: sources[pick.source].Name,
+			Value:  ms[pick.source].elems().Index(pick.index).Interface(),
+		}
+	}
+	return
+}